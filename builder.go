@@ -0,0 +1,81 @@
+package checkpoint
+
+import "regexp"
+
+// Builder accumulates Options to create Checkpoints fluently, which is handy if some options are conditional:
+//
+//	b := checkpoint.Build().Field("user", id).Code("E01")
+//	if fatal {
+//		b = b.Severity(checkpoint.SeverityFatal)
+//	}
+//	return b.From(err)
+//
+// A Builder is immutable: each method returns a new Builder and leaves the original untouched.
+// Therefore a Builder can safely be reused and shared, also between goroutines.
+// The zero value is a Builder without any options.
+type Builder struct {
+	options []Option
+}
+
+// Build returns a new Builder with the given initial options.
+func Build(options ...Option) Builder {
+	return Builder{options: append([]Option(nil), options...)}
+}
+
+// With returns a new Builder with the given options added.
+func (b Builder) With(options ...Option) Builder {
+	// Limit the capacity so that append always copies and the original Builder is not modified.
+	return Builder{options: append(b.options[:len(b.options):len(b.options)], options...)}
+}
+
+// Field adds WithField(key, value).
+func (b Builder) Field(key string, value interface{}) Builder {
+	return b.With(WithField(key, value))
+}
+
+// Fields adds WithFields(fields).
+func (b Builder) Fields(fields map[string]interface{}) Builder {
+	return b.With(WithFields(fields))
+}
+
+// Code adds WithCode(code).
+func (b Builder) Code(code string) Builder {
+	return b.With(WithCode(code))
+}
+
+// Status adds WithStatus(code).
+func (b Builder) Status(code int) Builder {
+	return b.With(WithStatus(code))
+}
+
+// Severity adds WithSeverity(s).
+func (b Builder) Severity(s Severity) Builder {
+	return b.With(WithSeverity(s))
+}
+
+// Redact adds Redact(patterns...).
+func (b Builder) Redact(patterns ...*regexp.Regexp) Builder {
+	return b.With(Redact(patterns...))
+}
+
+// Stack adds WithStack().
+func (b Builder) Stack() Builder {
+	return b.With(WithStack())
+}
+
+// Time adds WithTime().
+func (b Builder) Time() Builder {
+	return b.With(WithTime())
+}
+
+// From works like the package function From using the options of the Builder.
+// The caller information points to the call of this method.
+func (b Builder) From(err error) error {
+	return from(err, b.options, 1)
+}
+
+// Wrap works like the package function Wrap using the options of the Builder.
+// The caller information points to the call of this method.
+func (b Builder) Wrap(prev, err error) error {
+	return wrap(prev, err, b.options, 1)
+}
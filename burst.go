@@ -0,0 +1,84 @@
+package checkpoint
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// maxBurstSites limits the number of call sites tracked by BurstLimit.
+// If more call sites are limited, the bucket of the least recently limited call site is dropped,
+// so the memory stays bounded.
+const maxBurstSites = 1024
+
+// burstBucket is the token bucket of a single call site.
+type burstBucket struct {
+	pc     uintptr
+	tokens float64
+	last   time.Time
+}
+
+var (
+	burstMu      sync.Mutex
+	burstOrder   = list.New() // Most recently used first.
+	burstBuckets = make(map[uintptr]*list.Element)
+)
+
+// BurstLimit captures the caller information for at most perSecond Checkpoints per second
+// created at the same call site. Beyond that, Checkpoints are created as with NoCaller until
+// the limit allows further captures again.
+// In contrast to Sample, the first Checkpoints of a burst keep all their details, while the
+// overhead is reduced during sustained floods of errors from the same line.
+//
+// The limit is implemented by a token bucket per call site, which is keyed by the program counter,
+// so only the cheap lookup of the program counter is done for limited Checkpoints.
+// At most 1024 call sites are tracked at the same time; if more are limited, the bucket of the
+// least recently limited call site is dropped.
+// A perSecond of 0 or less disables the limit.
+//
+// All call sites share a single lock for their buckets, so Checkpoints created with BurstLimit
+// by many goroutines at the same time contend for it.
+func BurstLimit(perSecond int) Option {
+	return optionFunc(func(o *options) {
+		o.burst = perSecond
+	})
+}
+
+// burstLimited reports whether the caller information for the call site pc must not be captured
+// because its BurstLimit is exceeded.
+func (o options) burstLimited(pc uintptr) bool {
+	if o.burst <= 0 {
+		return false
+	}
+
+	now := time.Now()
+	burstMu.Lock()
+	defer burstMu.Unlock()
+
+	var bucket *burstBucket
+	if element, ok := burstBuckets[pc]; ok {
+		burstOrder.MoveToFront(element)
+		bucket = element.Value.(*burstBucket)
+	} else {
+		bucket = &burstBucket{pc: pc, tokens: float64(o.burst), last: now}
+		burstBuckets[pc] = burstOrder.PushFront(bucket)
+		if burstOrder.Len() > maxBurstSites {
+			oldest := burstOrder.Back()
+			burstOrder.Remove(oldest)
+			delete(burstBuckets, oldest.Value.(*burstBucket).pc)
+		}
+	}
+
+	// Refill the bucket according to the elapsed time.
+	bucket.tokens += now.Sub(bucket.last).Seconds() * float64(o.burst)
+	if bucket.tokens > float64(o.burst) {
+		bucket.tokens = float64(o.burst)
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return true
+	}
+	bucket.tokens--
+	return false
+}
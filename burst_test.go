@@ -0,0 +1,28 @@
+package checkpoint
+
+import (
+	"container/list"
+	"testing"
+)
+
+func TestBurstLimitKeepsActiveSites(t *testing.T) {
+	burstOrder.Init()
+	burstBuckets = make(map[uintptr]*list.Element)
+
+	o := options{burst: 1}
+	const hot = uintptr(1)
+	if o.burstLimited(hot) {
+		t.Fatalf("burstLimited() = true for the first Checkpoint")
+	}
+
+	// Flood with more call sites than are tracked while the hot site stays active.
+	for pc := uintptr(2); pc < 2+maxBurstSites; pc++ {
+		o.burstLimited(pc)
+		if pc%100 == 0 && !o.burstLimited(hot) {
+			t.Fatalf("burstLimited() = false for the exhausted call site after %d other call sites", pc-1)
+		}
+	}
+	if len(burstBuckets) != maxBurstSites {
+		t.Errorf("%d call sites are tracked, want %d", len(burstBuckets), maxBurstSites)
+	}
+}
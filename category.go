@@ -0,0 +1,79 @@
+package checkpoint
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Category classifies errors into a closed set of classes, e.g. for routing and metrics.
+// In contrast to free-form codes attached by WithCode, all categories are known,
+// so they can be handled by an exhaustive switch.
+// The zero value means that no category was set.
+type Category int
+
+// The supported categories.
+const (
+	CategoryNotFound Category = iota + 1
+	CategoryConflict
+	CategoryInternal
+	CategoryValidation
+)
+
+// String returns the lower case name of the category, e.g. "not_found".
+func (c Category) String() string {
+	switch c {
+	case 0:
+		return ""
+	case CategoryNotFound:
+		return "not_found"
+	case CategoryConflict:
+		return "conflict"
+	case CategoryInternal:
+		return "internal"
+	case CategoryValidation:
+		return "validation"
+	}
+	return "category(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Status returns the HTTP status code implied by the category.
+// It is used by HTTPStatus if no status was attached explicitly by WithStatus.
+// Unknown categories imply http.StatusInternalServerError.
+func (c Category) Status() int {
+	switch c {
+	case CategoryNotFound:
+		return http.StatusNotFound
+	case CategoryConflict:
+		return http.StatusConflict
+	case CategoryValidation:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// WithCategory sets the category of the Checkpoint.
+// The category of a whole chain can be retrieved by CategoryOf.
+func WithCategory(c Category) Option {
+	return optionFunc(func(o *options) {
+		o.category = c
+	})
+}
+
+// Category returns the category set for this Checkpoint by WithCategory.
+// It is 0 if no category was set.
+func (e *Checkpoint) Category() Category {
+	return e.category
+}
+
+// CategoryOf returns the outermost category set by WithCategory in the chain of err.
+// ok is false if no Checkpoint of the chain has a category.
+func CategoryOf(err error) (category Category, ok bool) {
+	Walk(err, func(c *Checkpoint) bool {
+		if c.category == 0 {
+			return true
+		}
+		category, ok = c.category, true
+		return false
+	})
+	return category, ok
+}
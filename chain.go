@@ -0,0 +1,391 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxChainLength limits how many layers are traversed when walking an error chain.
+// It protects against malformed (e.g. cyclic) chains which would otherwise never end.
+const maxChainLength = 10000
+
+// cycleMarker is rendered instead of the remaining layers of a cyclic chain.
+const cycleMarker = "(cycle detected)"
+
+// visited remembers the Checkpoints visited while traversing a chain to detect cycles.
+// Short chains are tracked in a small array to avoid allocating a map for every traversal.
+type visited struct {
+	short [16]*Checkpoint
+	n     int
+	long  map[*Checkpoint]struct{}
+}
+
+// first reports whether c is visited for the first time and remembers it.
+func (v *visited) first(c *Checkpoint) bool {
+	for _, s := range v.short[:v.n] {
+		if s == c {
+			return false
+		}
+	}
+	if v.n < len(v.short) {
+		v.short[v.n] = c
+		v.n++
+		return true
+	}
+
+	if v.long == nil {
+		v.long = make(map[*Checkpoint]struct{})
+	}
+	if _, ok := v.long[c]; ok {
+		return false
+	}
+	v.long[c] = struct{}{}
+	return true
+}
+
+// Root returns the innermost error of the chain of err by following Unwrap
+// until an error is reached which does not wrap any further error.
+// For a Checkpoint without previous error, the root is the error describing it.
+// It returns nil if err is nil.
+func Root(err error) error {
+	for i := 0; err != nil && i < maxChainLength; i++ {
+		if c, ok := asCheckpoint(err); ok {
+			if c.prev == nil {
+				if c.err == nil {
+					return err
+				}
+				return c.err
+			}
+			err = c.prev
+			continue
+		}
+
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return err
+}
+
+// Cause returns the original error of the chain of err, which is the deepest error that is not a Checkpoint.
+// It descends through all Checkpoints: if the innermost Checkpoint has a previous error which is
+// no Checkpoint, that error is returned, otherwise the error describing the innermost Checkpoint.
+// In contrast to Root, Cause does not unwrap errors which are no Checkpoints, so it can be used to
+// get e.g. the raw *os.PathError for a type switch even if it wraps further errors.
+// It returns err itself if it is no Checkpoint.
+func Cause(err error) error {
+	for i := 0; i < maxChainLength; i++ {
+		c, ok := asCheckpoint(err)
+		if !ok {
+			return err
+		}
+		if c.prev == nil {
+			if c.err == nil {
+				return c
+			}
+			return c.err
+		}
+		err = c.prev
+	}
+	return err
+}
+
+// Walk calls fn for each Checkpoint of the chain of err from the outermost to the innermost.
+// The walk stops as soon as fn returns false.
+// Errors which are no Checkpoints are skipped, but the chain is still followed through them
+// as long as they support Unwrap.
+// Errors wrapping multiple errors, such as those created by WrapMany, Merge or errors.Join, are walked depth first:
+// all Checkpoints of the first branch are visited before the ones of the second branch and so on.
+// Each Checkpoint is visited only once, so a cyclic chain ends before a Checkpoint would be visited a second time.
+func Walk(err error, fn func(c *Checkpoint) bool) {
+	var seen visited
+	steps := 0
+	var walk func(err error) bool
+	walk = func(err error) bool {
+		for ; err != nil && steps < maxChainLength; steps++ {
+			if c, ok := asCheckpoint(err); ok {
+				if !seen.first(c) {
+					// Already visited by a cycle or by another branch.
+					return true
+				}
+				if !fn(c) {
+					return false
+				}
+				err = c.prev
+				continue
+			}
+
+			if multi, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, branch := range multi.Unwrap() {
+					if !walk(branch) {
+						return false
+					}
+				}
+				return true
+			}
+			err = errors.Unwrap(err)
+		}
+		return true
+	}
+	walk(err)
+}
+
+// Depth returns the number of Checkpoints in the chain starting at this Checkpoint.
+// All Checkpoints visited by Walk are counted, including those in the branches of WrapMany and Merge.
+// Errors which are no Checkpoints are not counted.
+// A single From() therefore has a depth of 1.
+func (e *Checkpoint) Depth() int {
+	depth := 0
+	Walk(e, func(*Checkpoint) bool {
+		depth++
+		return true
+	})
+	return depth
+}
+
+// Flatten returns all Checkpoints of the chain of err in the order they are visited by Walk,
+// which is from the outermost to the innermost with branches visited depth first.
+// Errors which are no Checkpoints are skipped, but the chain is still followed through them
+// as long as they support Unwrap.
+// It returns nil if the chain contains no Checkpoint.
+func Flatten(err error) []*Checkpoint {
+	var res []*Checkpoint
+	Walk(err, func(c *Checkpoint) bool {
+		res = append(res, c)
+		return true
+	})
+	return res
+}
+
+// Files returns the distinct source files the Checkpoints of the chain of err were created in,
+// ordered by their first occurrence from the outermost to the innermost Checkpoint.
+// The files are returned as by Checkpoint.File, so they respect FullPath and ShortPath.
+// Checkpoints created at different lines of the same file are only counted once.
+// Checkpoints without caller information are skipped.
+func Files(err error) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	Walk(err, func(c *Checkpoint) bool {
+		if !c.callerOk {
+			return true
+		}
+		file := c.File()
+		if _, ok := seen[file]; !ok {
+			seen[file] = struct{}{}
+			res = append(res, file)
+		}
+		return true
+	})
+	return res
+}
+
+// CountFiles returns the number of distinct source files the Checkpoints of the chain of err were created in.
+// See Files for details.
+func CountFiles(err error) int {
+	return len(Files(err))
+}
+
+// AsAll returns all errors of the chain of err which are of type T, ordered from the outermost to the innermost.
+// In contrast to errors.As it does not stop at the first match. Every layer is checked,
+// including the errors describing the Checkpoints and all branches of errors created by WrapMany or Merge:
+//
+//	for _, v := range checkpoint.AsAll[*ValidationError](err) {
+//		fmt.Println(v.Field)
+//	}
+//
+// It returns nil if no error of the chain is of type T.
+func AsAll[T error](err error) []T {
+	var res []T
+	eachLayer(err, func(err error) bool {
+		if t, ok := err.(T); ok {
+			res = append(res, t)
+		}
+		return true
+	})
+	return res
+}
+
+// eachLayer calls fn for every layer of the chain of err ordered from the outermost to the innermost
+// until fn returns false.
+// For a Checkpoint, fn is called with the Checkpoint itself, followed by the chain of the error describing it
+// and then its previous error. All branches of errors wrapping multiple errors are visited.
+// At most maxChainLength layers are visited.
+func eachLayer(err error, fn func(err error) bool) {
+	visited := 0
+	var visit func(err error) bool
+	visit = func(err error) bool {
+		for err != nil && visited < maxChainLength {
+			visited++
+			if !fn(err) {
+				return false
+			}
+
+			if c, ok := asCheckpoint(err); ok {
+				if !visit(c.err) {
+					return false
+				}
+				err = c.prev
+				continue
+			}
+
+			if multi, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, branch := range multi.Unwrap() {
+					if !visit(branch) {
+						return false
+					}
+				}
+				return true
+			}
+			err = errors.Unwrap(err)
+		}
+		return true
+	}
+	visit(err)
+}
+
+// Find returns the outermost Checkpoint of the chain of err for which match returns true.
+// Errors which are no Checkpoints are skipped, but the chain is still followed through them
+// as long as they support Unwrap. For example, the outermost Checkpoint carrying a "user" field
+// can be found by
+//
+//	c, ok := checkpoint.Find(err, func(c *checkpoint.Checkpoint) bool {
+//		_, ok := c.Fields()["user"]
+//		return ok
+//	})
+//
+// ok is false if no Checkpoint of the chain matches.
+func Find(err error, match func(c *Checkpoint) bool) (*Checkpoint, bool) {
+	var found *Checkpoint
+	Walk(err, func(c *Checkpoint) bool {
+		if match(c) {
+			found = c
+			return false
+		}
+		return true
+	})
+	return found, found != nil
+}
+
+// OriginOf returns the Checkpoint at which target entered the chain of err, which is the innermost Checkpoint
+// whose describing error or whose previous error that is no Checkpoint matches target according to errors.Is.
+// This allows finding the location where a known error such as sql.ErrNoRows first occurred.
+// It returns false if target is not part of any Checkpoint of the chain.
+func OriginOf(err, target error) (*Checkpoint, bool) {
+	var origin *Checkpoint
+	Walk(err, func(c *Checkpoint) bool {
+		if errors.Is(c.err, target) || (!isCheckpoint(c.prev) && errors.Is(c.prev, target)) {
+			origin = c
+		}
+		return true
+	})
+	return origin, origin != nil
+}
+
+// Bare returns the error chain of err without any Checkpoints, e.g. to pass it across a trust boundary
+// where the caller information must not leak.
+// Each Checkpoint is replaced by its describing error combined with its bare previous error
+// as by fmt.Errorf("%w: %w", describing, prev), and errors created by WrapMany or Merge are
+// replaced by errors.Join of their bare branches.
+// If ReplaceMessage or Redact was used for a Checkpoint, the describing error is wrapped so that
+// its message is the replaced or redacted one of Checkpoint.Message, so no scrubbed text leaks.
+// Therefore errors.Is and errors.As behave in the same way as for err.
+// Errors which are no Checkpoints are kept unchanged, including any Checkpoints they wrap.
+// It returns nil if err is nil.
+func Bare(err error) error {
+	var seen visited
+	return bare(err, &seen)
+}
+
+// bare implements Bare.
+func bare(err error, seen *visited) error {
+	if m, ok := err.(multiError); ok {
+		errs := make([]error, len(m.errs))
+		for i, branch := range m.errs {
+			errs[i] = bare(branch, seen)
+		}
+		return errors.Join(errs...)
+	}
+
+	c, ok := asCheckpoint(err)
+	if !ok {
+		return err
+	}
+	if !seen.first(c) {
+		return errors.New(cycleMarker)
+	}
+
+	describing := c.err
+	if c.message != "" || len(c.redactions) > 0 {
+		describing = bareError{message: c.Message(), err: c.err}
+	}
+
+	prev := bare(c.prev, seen)
+	switch {
+	case describing == nil:
+		return prev
+	case prev == nil:
+		return describing
+	}
+	return fmt.Errorf("%w: %w", describing, prev)
+}
+
+// bareError replaces the describing error of a Checkpoint in Bare if ReplaceMessage or Redact was used,
+// so its message is the one of Checkpoint.Message while errors.Is and errors.As still find the original error.
+type bareError struct {
+	message string
+	err     error
+}
+
+func (e bareError) Error() string {
+	return e.message
+}
+
+func (e bareError) Unwrap() error {
+	return e.err
+}
+
+// Adopt converts a chain of errors which are no Checkpoints, e.g. created by fmt.Errorf("...: %w", err),
+// into a checkpoint chain, so it can be inspected and rendered like any other checkpoint chain.
+// Each layer of the Unwrap chain becomes a Checkpoint without caller information, which is described by the
+// original layer, so errors.Is and errors.As keep working. Its message is the message of the layer without
+// the message of the wrapped error, if the layer ends with ": " followed by it.
+// The conversion stops at the first Checkpoint and at errors wrapping multiple errors, which are kept unchanged.
+// It returns err unchanged if it is nil or already a Checkpoint.
+func Adopt(err error) error {
+	if err == nil || isCheckpoint(err) {
+		return err
+	}
+
+	var root, last *Checkpoint
+	for i := 0; err != nil && i < maxChainLength; i++ {
+		if isCheckpoint(err) {
+			last.prev = err
+			break
+		}
+		if _, ok := err.(interface{ Unwrap() []error }); ok && last != nil {
+			last.prev = err
+			break
+		}
+
+		c := &Checkpoint{err: err}
+		next := errors.Unwrap(err)
+		if next != nil {
+			message := err.Error()
+			if own, ok := strings.CutSuffix(message, ": "+next.Error()); ok {
+				c.message = own
+			}
+		}
+
+		if root == nil {
+			root = c
+		} else {
+			last.prev = c
+		}
+		last = c
+		err = next
+	}
+	return root
+}
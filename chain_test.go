@@ -0,0 +1,232 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCausePathError(t *testing.T) {
+	_, openErr := os.Open("does-not-exist.txt")
+
+	err := From(openErr)
+	err = Wrap(err, errors.New("read config"))
+	err = Wrap(err, errors.New("start"))
+
+	var pathErr *os.PathError
+	switch cause := Cause(err).(type) {
+	case *os.PathError:
+		pathErr = cause
+	default:
+		t.Fatalf("Cause() = %T, want *os.PathError", cause)
+	}
+	if pathErr != openErr {
+		t.Errorf("Cause() = %v, want the original error %v", pathErr, openErr)
+	}
+}
+
+func TestSelfReferentialChainTerminates(t *testing.T) {
+	c, _ := asCheckpoint(From(errors.New("loop")))
+	c.prev = c
+
+	if got := c.Error(); !strings.HasSuffix(got, cycleMarker) {
+		t.Errorf("Error() does not end with the cycle marker:\n%s", got)
+	}
+	if got := c.Frames(); len(got) != 2 || got[1].Message != cycleMarker {
+		t.Errorf("Frames() = %v, want the Checkpoint followed by the cycle marker", got)
+	}
+	if got := Flatten(c); len(got) != 1 {
+		t.Errorf("Flatten() returned %d Checkpoints, want 1", len(got))
+	}
+	if got := c.Depth(); got != 1 {
+		t.Errorf("Depth() = %d, want 1", got)
+	}
+}
+
+func TestWalkBranches(t *testing.T) {
+	x, y := From(errors.New("x")), From(errors.New("y"), WithStatus(404))
+	c, _ := asCheckpoint(WrapMany(errors.New("top"), x, y))
+
+	if got := c.Depth(); got != 3 {
+		t.Errorf("Depth() = %d, want 3", got)
+	}
+	if got := Flatten(c); len(got) != 3 || got[1] != x || got[2] != y {
+		t.Errorf("Flatten() = %v, want the Checkpoint followed by both branches in order", got)
+	}
+	if got := HTTPStatus(Merge(y, errors.New("other"))); got != 404 {
+		t.Errorf("HTTPStatus() = %d, want 404 from a Merge branch", got)
+	}
+}
+
+func TestBareKeepsRedaction(t *testing.T) {
+	original := errors.New("token=abcd1234 failed")
+	err := Wrap(From(original, Redact(regexp.MustCompile(`token=\w+`))), errors.New("login"), ReplaceMessage("login failed"))
+
+	got := Bare(err)
+	if want := "login failed: *** failed"; got.Error() != want {
+		t.Errorf("Bare().Error() = %q, want %q", got.Error(), want)
+	}
+	if !errors.Is(got, original) {
+		t.Errorf("errors.Is(Bare(), original) = false, want true")
+	}
+}
+
+// branchChain returns a WrapMany Checkpoint with a foreign error in its first branch.
+func branchChain() *Checkpoint {
+	x := Wrap(errors.New("io"), errors.New("x"))
+	y := From(errors.New("y"))
+	c, _ := asCheckpoint(WrapMany(errors.New("top"), x, y))
+	return c
+}
+
+func TestFramesOfBranches(t *testing.T) {
+	c := branchChain()
+
+	var got []string
+	checkpoints := 0
+	for _, frame := range c.Frames() {
+		got = append(got, frame.Branch+":"+frame.Message)
+		if frame.File != "" {
+			checkpoints++
+		}
+	}
+	if want := []string{":top", "0:x", "0:io", "1:y"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Frames() = %v, want %v", got, want)
+	}
+	if checkpoints != c.Depth() {
+		t.Errorf("Frames() contains %d Checkpoints, but Depth() = %d", checkpoints, c.Depth())
+	}
+	if got := c.OneLine(); !regexp.MustCompile(`: top <- \[0\] .*: x <- \[0\] io <- \[1\] .*: y$`).MatchString(got) {
+		t.Errorf("OneLine() does not label the branches: %s", got)
+	}
+}
+
+func TestJSONOfBranches(t *testing.T) {
+	c := branchChain()
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res struct {
+		Prev  json.RawMessage `json:"prev"`
+		Prevs []struct {
+			Message string `json:"message"`
+			Prev    *struct {
+				Message string `json:"message"`
+			} `json:"prev"`
+		} `json:"prevs"`
+		Frames []struct {
+			Message string `json:"message"`
+			Branch  string `json:"branch"`
+		} `json:"frames"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Prev != nil || len(res.Prevs) != 2 || res.Prevs[0].Message != "x" || res.Prevs[0].Prev == nil ||
+		res.Prevs[0].Prev.Message != "io" || res.Prevs[1].Message != "y" {
+		t.Errorf("MarshalJSON() does not nest the branches as prevs:\n%s", data)
+	}
+	if len(res.Frames) != 4 || res.Frames[2].Branch != "0" || res.Frames[3].Branch != "1" {
+		t.Errorf("MarshalJSON() does not contain the branches of the frames:\n%s", data)
+	}
+
+	var restored Checkpoint
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Prevs(); len(got) != 2 || got[0].Error() != c.Prevs()[0].Error() || got[1].Error() != c.Prevs()[1].Error() {
+		t.Errorf("UnmarshalJSON() restored the branches %v, want %v", got, c.Prevs())
+	}
+	if restored.OneLine() != c.OneLine() {
+		t.Errorf("OneLine() after UnmarshalJSON() = %s, want %s", restored.OneLine(), c.OneLine())
+	}
+}
+
+func TestJSONLinesOfBranches(t *testing.T) {
+	data, err := branchChain().JSONLines()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var line struct {
+			Index   int    `json:"index"`
+			Message string `json:"message"`
+			Branch  string `json:"branch"`
+			Foreign bool   `json:"foreign"`
+		}
+		if err := dec.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, fmt.Sprintf("%d %s:%s %t", line.Index, line.Branch, line.Message, line.Foreign))
+	}
+	want := []string{"0 :top false", "1 0:x false", "2 0:io true", "3 1:y false"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("JSONLines() = %q, want %q", got, want)
+	}
+}
+
+func TestLogValueOfBranches(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", "err", branchChain())
+
+	var res struct {
+		Err struct {
+			Prevs map[string]struct {
+				Message string `json:"message"`
+			} `json:"prevs"`
+		} `json:"err"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if prevs := res.Err.Prevs; len(prevs) != 2 || prevs["0"].Message != "x" || prevs["1"].Message != "y" {
+		t.Errorf("LogValue() does not group the branches:\n%s", buf.String())
+	}
+}
+
+func TestDiffOfBranches(t *testing.T) {
+	c := branchChain()
+	other := WrapMany(errors.New("top"), c.Prevs()[0], From(errors.New("z")))
+
+	got := Diff(c, other, IgnoreCaller())
+	if !strings.Contains(got, "- [1] ") || !strings.Contains(got, ": y") || !strings.Contains(got, "+ [1] ") || strings.Contains(got, "[0]") {
+		t.Errorf("Diff() does not report only the differing branch:\n%s", got)
+	}
+}
+
+func TestJSONOfMerge(t *testing.T) {
+	c, _ := asCheckpoint(Merge(From(errors.New("x")), errors.New("y")))
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"merged":true`)) || !bytes.Contains(data, []byte(`"branch":"1"`)) {
+		t.Errorf("MarshalJSON() does not mark the Merge and its branches:\n%s", data)
+	}
+
+	var restored Checkpoint
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := restored.Error(), c.Error(); got != want {
+		t.Errorf("Error() after UnmarshalJSON() = %q, want %q", got, want)
+	}
+	if !strings.Contains(restored.Error(), "[1] y") {
+		t.Errorf("Error() after UnmarshalJSON() does not label the branches: %q", restored.Error())
+	}
+	if got := restored.OneLine(); !strings.Contains(got, "2 errors <- [0] ") || !strings.Contains(got, "<- [1] y") {
+		t.Errorf("OneLine() after UnmarshalJSON() = %s, want both branches labeled", got)
+	}
+}
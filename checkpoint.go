@@ -1,43 +1,30 @@
 // Package checkpoint provides a way to decorate errors by some additional caller information
 // which results in something similar to a stacktrace.
 // Each error added to a Checkpoint can be checked by errors.Is and retrieved by errors.As.
+//
+// The package is configured by package level variables such as FilePrefix, Indent or MaxDepth,
+// which should only be modified during initialization, and by functions such as SetFormatter,
+// FilterRender, SetContextFields or SetDefaultOptions, which may also be called concurrently.
+// All of this configuration is shared by all goroutines.
+//
+// Integrations with other libraries, such as zap, logrus, OpenTelemetry or gRPC, are provided by
+// subpackages which are separate modules, so this package only depends on the standard library.
 package checkpoint
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// IgnoreEOF returns the io.EOF and io.ErrUnexpectedEOF directly instead of wrapping it.
-// This may be needed to be compatible to several io functions from the standard lib and from other libs.
-// These often check for io.EOF by equality and not by errors.Is because of historical reasons.
-// See https://github.com/golang/go/issues/39155
-func IgnoreEOF() Option {
-	return func(err error) error {
-		if err == io.EOF {
-			return io.EOF
-		}
-		if err == io.ErrUnexpectedEOF {
-			return io.ErrUnexpectedEOF
-		}
-
-		return nil
-	}
-}
-
-// Option defines a special error handling function.
-// If it returns nil the normal error handling is done.
-// Else the returned error is returned instead of the Checkpoint.
-//
-// This can be used to define special error handling for special errors
-// such as io.EOF.
-type Option = func(err error) error
-
 func relativeFile(file string) string {
 	dir, err := os.Getwd()
 	if err != nil {
@@ -51,32 +38,146 @@ func relativeFile(file string) string {
 	return path
 }
 
+// pathMode defines how the path of the source file is recorded.
+type pathMode int
+
+const (
+	// pathRelative records the path relative to the working directory.
+	pathRelative pathMode = iota
+	// pathFull records the complete path.
+	pathFull
+	// pathShort records only the last directory and the file name.
+	pathShort
+	// pathBase records only the file name.
+	pathBase
+)
+
+// format converts the full path of a source file according to the mode.
+func (m pathMode) format(file string) string {
+	switch m {
+	case pathFull:
+		return file
+	case pathShort:
+		dir, name := filepath.Split(file)
+		return filepath.Join(filepath.Base(dir), name)
+	case pathBase:
+		return filepath.Base(file)
+	}
+	return relativeFile(file)
+}
+
+// callerPC returns the program counter of the caller of the function calling callerPC.
+// skip works like the argument to runtime.Caller.
+func callerPC(skip int) (pc uintptr, ok bool) {
+	var pcs [1]uintptr
+	// Skip runtime.Callers and callerPC itself.
+	if runtime.Callers(skip+2, pcs[:]) < 1 {
+		return 0, false
+	}
+	return pcs[0], true
+}
+
+// callerFrame resolves the full file path, line, short function name and package import path
+// of the program counter returned by callerPC.
+func callerFrame(pc uintptr) (file string, line int, fn, pkg string, ok bool) {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.PC == 0 {
+		return "", 0, "", "", false
+	}
+	return frame.File, frame.Line, shortFunc(frame.Function), packagePath(frame.Function), true
+}
+
+// packagePath returns the import path of the package of a fully qualified function name such as
+// "github.com/aligator/checkpoint.(*Checkpoint).Error", which is "github.com/aligator/checkpoint".
+func packagePath(fn string) string {
+	dir := ""
+	if i := strings.LastIndex(fn, "/"); i >= 0 {
+		dir, fn = fn[:i+1], fn[i+1:]
+	}
+	if i := strings.Index(fn, "."); i >= 0 {
+		fn = fn[:i]
+	}
+	// Dots in the last path element are escaped by the linker, e.g. "gopkg.in/yaml%2ev3".
+	return dir + strings.ReplaceAll(fn, "%2e", ".")
+}
+
+// shortFunc reduces a fully qualified function name such as
+// "github.com/aligator/checkpoint.From" to its last path element "checkpoint.From".
+func shortFunc(fn string) string {
+	if i := strings.LastIndex(fn, "/"); i >= 0 {
+		return fn[i+1:]
+	}
+	return fn
+}
+
 // From just wraps an error by a new Checkpoint which adds some caller information to the error.
 // It returns nil, if err == nil.
 // You may use Options to change the resulting error for some specific input-errors.
 // (Such as IgnoreEOF for special EOF handling)
 func From(err error, options ...Option) error {
-	for _, o := range options {
-		if newErr := o(err); newErr != nil {
-			return newErr
-		}
+	return from(err, options, 1)
+}
+
+// from implements From.
+// skip is the number of stack frames to ascend starting at the caller of from.
+func from(err error, options []Option, skip int) error {
+	o := newOptions(options)
+	if newErr := o.handle(err); newErr != nil {
+		return newErr
 	}
 
 	if err == nil {
 		return nil
 	}
+	if !o.wraps(err) {
+		return err
+	}
 
-	// Get the caller information.
-	_, file, line, ok := runtime.Caller(1)
-
-	return Checkpoint{
-		err:  err,
-		prev: nil,
+	return o.checkpoint(err, nil, skip+1)
+}
 
-		callerOk: ok,
-		file:     relativeFile(file),
-		line:     line,
+// Defer wraps the error pointed to by errp in place like From(*errp, options...).
+// It is intended to be deferred in functions with a named error result:
+//
+//	func load() (err error) {
+//		defer checkpoint.Defer(&err)
+//		...
+//	}
+//
+// The caller information points to the function which deferred Defer and not to a closure,
+// as it would with defer func() { err = checkpoint.From(err) }().
+// The recorded line is the one at which that function returned, as reported by the runtime.
+// If errp or *errp is nil, nothing is changed.
+func Defer(errp *error, options ...Option) {
+	if errp == nil || *errp == nil {
+		return
 	}
+	*errp = from(*errp, options, 1)
+}
+
+// Decorate runs fn and wraps the returned error like From(err, options...).
+// The caller information points to the call of Decorate, which makes it a small building block
+// for decorating all errors of a closure uniformly:
+//
+//	err := checkpoint.Decorate(func() error {
+//		return store.Save(item)
+//	}, checkpoint.WithCode("save_failed"))
+//
+// It returns nil, if fn returns nil.
+func Decorate(fn func() error, options ...Option) error {
+	return from(fn(), options, 1)
+}
+
+// New creates a new Checkpoint for a new error with the given message.
+// It behaves like From(errors.New(message)) with the caller information pointing to the call of New.
+func New(message string) error {
+	return from(errors.New(message), nil, 1)
+}
+
+// Newf creates a new Checkpoint for a new error created by fmt.Errorf(format, args...).
+// It behaves like From(fmt.Errorf(format, args...)) with the caller information pointing to the call of Newf.
+func Newf(format string, args ...interface{}) error {
+	return from(fmt.Errorf(format, args...), nil, 1)
 }
 
 // Wrap adds a Checkpoint with some caller information from an error and accepts
@@ -88,90 +189,546 @@ func From(err error, options ...Option) error {
 // Returns nil if prev == nil.
 // If err is nil, it still creates a Checkpoint.
 // This allows for example to predefine some errors and use them later:
-//  var(
-//  		ErrSomethingSpecialWentWrong = errors.New("a very bad error")
-//  )
-//  func someFunction() error {
-//  	err := somethingOtherThatThrowsErrors()
-//  	return checkpoint.Wrap(err, ErrSomethingSpecialWentWrong)
-//  }
-//
-//  err := someFunction()
+//
+//	var(
+//			ErrSomethingSpecialWentWrong = errors.New("a very bad error")
+//	)
+//	func someFunction() error {
+//		err := somethingOtherThatThrowsErrors()
+//		return checkpoint.Wrap(err, ErrSomethingSpecialWentWrong)
+//	}
+//
+//	err := someFunction()
+//
 // If used that way, you can still check with errors.Is() and errors.As() for the ErrSomethingSpecialWentWrong
-//  if errors.Is(err, ErrSomethingSpecialWentWrong) {
-//  	fmt.Println("The special error was thrown")
-//  } else {
-//  	fmt.Println(err)
-//  }
+//
+//	if errors.Is(err, ErrSomethingSpecialWentWrong) {
+//		fmt.Println("The special error was thrown")
+//	} else {
+//		fmt.Println(err)
+//	}
+//
 // but also for the error returned by somethingOtherThatThrowsErrors() (if you know what error it is).
 // If the error in this example is nil, no Checkpoint gets created.
 func Wrap(prev, err error, options ...Option) error {
-	for _, o := range options {
-		if newErr := o(err); newErr != nil {
+	return wrap(prev, err, options, 1)
+}
+
+// WrapIf works like Wrap if cond is true and returns prev unchanged otherwise.
+// It replaces branches such as
+//
+//	if cond {
+//		err = checkpoint.Wrap(err, ErrSomethingSpecialWentWrong)
+//	}
+//
+// by err = checkpoint.WrapIf(cond, err, ErrSomethingSpecialWentWrong) with the caller information
+// pointing to the call of WrapIf.
+// Like Wrap, it returns nil if prev == nil.
+func WrapIf(cond bool, prev, err error, options ...Option) error {
+	if !cond {
+		return prev
+	}
+	return wrap(prev, err, options, 1)
+}
+
+// wrap implements Wrap.
+// skip is the number of stack frames to ascend starting at the caller of wrap.
+func wrap(prev, err error, options []Option, skip int) error {
+	o := newOptions(options)
+	if newErr := o.handle(err); newErr != nil {
+		return newErr
+	}
+
+	if prev == nil {
+		return nil
+	}
+	if !o.wraps(prev) {
+		return prev
+	}
+
+	return o.checkpoint(err, prev, skip+1)
+}
+
+// Annotate adds metadata to the outermost Checkpoint of err without creating a new layer.
+// If err is a Checkpoint, a shallow copy of it with the metadata of the options applied is returned,
+// so the original error is not modified.
+// Otherwise err is wrapped once like From(err, options...) with the caller information pointing to the call of Annotate.
+// It returns nil, if err == nil.
+//
+// Only the metadata options and PostOptions are applied to an existing Checkpoint.
+// The metadata options are WithField, WithFields, WithCode, WithStatus, WithSeverity, WithCategory, WithRetry,
+// WithContext, ReplaceMessage, Redact and Truncate.
+// Options which affect the creation of a Checkpoint, such as Handlers (e.g. IgnoreEOF), FullPath, Skip,
+// WithStack or WithTime, are ignored in that case as no new caller information is captured.
+func Annotate(err error, options ...Option) error {
+	c, ok := asCheckpoint(err)
+	if !ok {
+		o := newOptions(options)
+		if newErr := o.handle(err); newErr != nil {
 			return newErr
 		}
+
+		if err == nil {
+			return nil
+		}
+		if !o.wraps(err) {
+			return err
+		}
+		return o.checkpoint(err, nil, 1)
+	}
+
+	// The default options were already applied when the Checkpoint was created.
+	o := collectOptions(options)
+
+	annotated := *c
+	// The stack may be owned by the pool, so the copy must not share it (see Release).
+	annotated.stack = append(c.stack[:0:0], c.stack...)
+	o.annotate(&annotated)
+	for _, p := range o.post {
+		p(&annotated)
+	}
+	return &annotated
+}
+
+// Fromf wraps err by a new Checkpoint which is described by the message fmt.Errorf(format, args...).
+// It is a shorthand for
+//
+//	checkpoint.Wrap(err, fmt.Errorf(format, args...))
+//
+// with the caller information pointing to the call of Fromf.
+// It returns nil, if err == nil.
+//
+// As the variadic arguments are used for the format, Fromf accepts no Options.
+// If special error handling such as IgnoreEOF is needed for err, use From instead and
+// describe the error with Wrap.
+func Fromf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
 	}
 
+	return wrap(err, fmt.Errorf(format, args...), nil, 1)
+}
+
+// Wrapf works like Wrap but creates the describing error using fmt.Errorf(format, args...).
+// So %w can be used in the format to wrap additional errors.
+// The caller information points to the call of Wrapf.
+//
+// Returns nil if prev == nil.
+func Wrapf(prev error, format string, args ...interface{}) error {
 	if prev == nil {
 		return nil
 	}
 
-	// Get the caller information.
-	_, file, line, ok := runtime.Caller(1)
+	return wrap(prev, fmt.Errorf(format, args...), nil, 1)
+}
 
-	return Checkpoint{
+// checkpoint creates a new Checkpoint based on the options.
+// skip is the number of stack frames to ascend starting at the caller of checkpoint.
+func (o options) checkpoint(err, prev error, skip int) *Checkpoint {
+	c := checkpointPool.Get().(*Checkpoint)
+	*c = Checkpoint{
 		err:  err,
 		prev: prev,
+	}
+
+	if o.capture() {
+		// Get the caller information.
+		pc, ok := callerPC(skip + 1 + o.skip)
+		if ok && o.burstLimited(pc) {
+			ok = false
+		}
+		if ok {
+			var file string
+			file, c.line, c.fn, c.pkg, ok = callerFrame(pc)
+			if ok {
+				c.pc = pc
+				c.file = o.path.format(file)
+				if o.source {
+					c.source = &source{file: file, line: c.line}
+				}
+			}
+		}
+		c.callerOk = ok
 
-		callerOk: ok,
-		file:     relativeFile(file),
-		line:     line,
+		if ok && o.stack {
+			c.stack = callers(skip + 1 + o.skip)
+			c.keepFrame = o.keepFrame
+		}
+	}
+	if o.time {
+		c.time = time.Now()
 	}
+	if o.goroutine {
+		c.goroutine = goroutineID()
+	}
+	if o.id {
+		c.id = newID()
+	}
+	o.annotate(c)
+	for _, p := range o.post {
+		p(c)
+	}
+	return c
 }
 
+// annotate applies all metadata of the options to c.
+// Metadata which is not set by the options is left unchanged.
+func (o options) annotate(c *Checkpoint) {
+	if len(o.redact) > 0 {
+		c.redactions = append(c.redactions[:len(c.redactions):len(c.redactions)], o.redact...)
+	}
+	if len(o.fields) > 0 {
+		if len(c.fields) == 0 {
+			c.fields = o.fields
+		} else {
+			fields := make(map[string]interface{}, len(c.fields)+len(o.fields))
+			for key, value := range c.fields {
+				fields[key] = value
+			}
+			for key, value := range o.fields {
+				fields[key] = value
+			}
+			c.fields = fields
+		}
+	}
+	if o.message != "" {
+		c.message = o.message
+	}
+	if o.truncate > 0 {
+		c.truncateAt = o.truncate
+	}
+	if o.code != "" {
+		c.code = o.code
+	}
+	if o.status != 0 {
+		c.status = o.status
+	}
+	if o.severity != 0 {
+		c.severity = o.severity
+	}
+	if o.category != 0 {
+		c.category = o.category
+	}
+	if o.retry {
+		c.retry = true
+		c.retryAfter = o.retryAfter
+	}
+	if o.ctx != nil {
+		c.ctx = o.ctx
+	}
+}
+
+// Checkpoint is an error decorated with the caller information of the location it was created at.
+// It is created by From, Wrap and the related functions, which all return a *Checkpoint.
+//
+// Migration note: earlier versions returned Checkpoint values.
+// As all methods now have pointer receivers, only *Checkpoint implements error,
+// so errors.As has to be used with a *Checkpoint target:
+//
+//	var c *checkpoint.Checkpoint
+//	if errors.As(err, &c) {
+//		fmt.Println(c.File(), c.Line())
+//	}
+//
+// errors.Is and errors.As behave exactly as before.
 type Checkpoint struct {
 	err  error
 	prev error
 
 	callerOk bool
+	pc       uintptr
 	file     string
 	line     int
+	fn       string
+	pkg      string
+
+	stack      []uintptr
+	keepFrame  func(frame runtime.Frame) bool
+	time       time.Time
+	goroutine  uint64
+	source     *source
+	id         string
+	message    string
+	redactions []*regexp.Regexp
+	truncateAt int
+	fields     map[string]interface{}
+	code       string
+	status     int
+	severity   Severity
+	category   Category
+	retry      bool
+	retryAfter time.Duration
+	ctx        context.Context
 }
 
-func (e Checkpoint) Error() string {
-	prevErrString := ""
-	if e.prev != nil {
+func (e *Checkpoint) Error() string {
+	var b strings.Builder
+	_, _ = e.WriteTo(&b)
+	return b.String()
+}
+
+// WriteTo writes the same output as Error() to w without building the whole string in memory first.
+// It implements io.WriterTo, so it can also be used with io.Copy.
+// If writing fails, it returns early with the number of bytes written so far.
+func (e *Checkpoint) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+	separate := false
+	write := func(s string) error {
+		// Separate the layers by line breaks without a trailing one.
+		if separate {
+			s = "\n" + s
+		}
+		separate = true
+
+		n, err := io.WriteString(w, s)
+		written += int64(n)
+		return err
+	}
+
+	depth := 0
+	var seen visited
+	c := e
+	for {
+		if !seen.first(c) {
+			return written, write(cycleMarker)
+		}
+		if depthExceeded(depth) {
+			return written, write(truncatedLayers(c))
+		}
+
+		repeats, prev := c.collapse()
+		if rendered(c) {
+			if err := write(withRepeats(formatNode(c), repeats)); err != nil {
+				return written, err
+			}
+			depth++
+		}
+
+		if prev == nil {
+			return written, nil
+		}
+
 		// Use different formatting for the prev error if it was not also a Checkpoint.
-		prevErrString = e.prev.Error()
-		_, ok := e.prev.(*Checkpoint)
-		if !ok {
-			prevErrString = "File: unknown\n\t" + strings.ReplaceAll(prevErrString, "\n", "\n\t")
+		if next, ok := asCheckpoint(prev); ok {
+			c = next
+			continue
 		}
+
+		if depthExceeded(depth) {
+			return written, write(truncatedLayers(prev))
+		}
+
+		if m, ok := prev.(multiError); ok {
+			return written, write(m.render())
+		}
+		return written, write(formatForeign(prev))
+	}
+}
+
+// header returns the first line of the rendered Checkpoint containing the caller information.
+func (e *Checkpoint) header() string {
+	var b strings.Builder
+
+	if e.id != "" {
+		b.WriteString("[")
+		b.WriteString(e.id)
+		b.WriteString("] ")
 	}
 
 	// Format different based on existing caller information.
 	if e.callerOk {
-		return fmt.Sprintf("File: %s:%d\n\t%v\n%v", e.file, e.line, e.err, prevErrString)
+		b.WriteString(FilePrefix)
+		b.WriteString(e.File())
+		b.WriteString(LineSeparator)
+		b.WriteString(strconv.Itoa(e.line))
+		if e.fn != "" {
+			b.WriteString(" (")
+			b.WriteString(e.fn)
+			b.WriteString(")")
+		}
+	} else {
+		b.WriteString(FilePrefix)
+		b.WriteString(callerUnavailable)
+	}
+
+	if e.goroutine != 0 {
+		b.WriteString(" [goroutine ")
+		b.WriteString(strconv.FormatUint(e.goroutine, 10))
+		b.WriteString("]")
+	}
+
+	if !e.time.IsZero() {
+		b.WriteString(" at ")
+		b.WriteString(e.time.Format(TimeLayout))
+	}
+
+	if len(e.fields) > 0 {
+		b.WriteString(" ")
+		b.WriteString(formatFields(e.fields))
 	}
-	return fmt.Sprintf("File: unknown\n\t%v\n%v", e.err, prevErrString)
+	return b.String()
+}
+
+// Format implements fmt.Formatter.
+//
+//	%s, %+v  print the full checkpoint chain (same as Error())
+//	%v       prints only the message of this Checkpoint on a single line
+//	%q       prints the full checkpoint chain as quoted string
+//	%#v      prints a Go-syntax representation of the Checkpoint
+//
+// Width and flags are passed on to the underlying string formatting.
+func (e *Checkpoint) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('#') {
+			_, _ = io.WriteString(s, e.GoString())
+			return
+		}
+		if s.Flag('+') {
+			_, _ = fmt.Fprintf(s, fmt.FormatString(s, 's'), e.Error())
+			return
+		}
+		_, _ = fmt.Fprintf(s, fmt.FormatString(s, 's'), e.summary())
+	case 's', 'q':
+		_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), e.Error())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(checkpoint.Checkpoint=%s)", verb, e.summary())
+	}
+}
+
+// GoString implements fmt.GoStringer and is used for %#v.
+func (e *Checkpoint) GoString() string {
+	return fmt.Sprintf("&checkpoint.Checkpoint{err:%#v, prev:%#v, callerOk:%t, file:%q, line:%d, fn:%q}", e.err, e.prev, e.callerOk, e.file, e.line, e.fn)
+}
+
+// summary returns the message of this Checkpoint on one line.
+// If the Checkpoint has no own error, the summary of prev is used.
+func (e *Checkpoint) summary() string {
+	if e.err == nil && e.message == "" && e.prev != nil {
+		return strings.ReplaceAll(fmt.Sprintf("%v", e.prev), "\n", " ")
+	}
+	return strings.ReplaceAll(e.Message(), "\n", " ")
+}
+
+// isCheckpoint reports whether err itself (not any wrapped error) is a Checkpoint.
+func isCheckpoint(err error) bool {
+	_, ok := asCheckpoint(err)
+	return ok
 }
 
-func (e Checkpoint) Unwrap() error {
+// asCheckpoint returns err as Checkpoint if err itself (not any wrapped error)
+// is a non nil Checkpoint.
+func asCheckpoint(err error) (*Checkpoint, bool) {
+	c, ok := err.(*Checkpoint)
+	return c, ok && c != nil
+}
+
+// Unwrap returns the previous error of the Checkpoint.
+// The error describing the Checkpoint is not returned, as it is already searched by Is and As.
+// This keeps the chain linear, so errors.Unwrap iterates the layers from the outermost to the innermost.
+func (e *Checkpoint) Unwrap() error {
 	return e.prev
 }
 
-func (e Checkpoint) Is(target error) bool {
+// Is reports whether the error describing the Checkpoint or any error wrapped by it matches target.
+// Together with Unwrap, errors.Is therefore finds target in the describing errors and the previous errors
+// of the whole chain.
+func (e *Checkpoint) Is(target error) bool {
 	return errors.Is(e.err, target)
 }
 
-func (e Checkpoint) As(target interface{}) bool {
+// As finds the first error in the chain of the error describing the Checkpoint that matches target.
+// As errors.As is used, errors wrapped by the describing error (e.g. by fmt.Errorf("...: %w", inner))
+// are found as well.
+func (e *Checkpoint) As(target interface{}) bool {
 	return errors.As(e.err, target)
 }
 
-func (e Checkpoint) File() string {
-	return e.file
+// File returns the source file the Checkpoint was created in.
+// It is relative to the working directory unless FullPath was used.
+// ModuleRoot is trimmed from the beginning of the path.
+// It is empty if no caller information is available.
+func (e *Checkpoint) File() string {
+	return trimModuleRoot(e.file)
 }
 
-func (e Checkpoint) Line() int {
+// Line returns the line in File the Checkpoint was created at.
+func (e *Checkpoint) Line() int {
 	return e.line
 }
+
+// Location returns the file and line the Checkpoint was created at as "file:line",
+// separated by LineSeparator as in the output of Error().
+// The file respects FullPath, ShortPath and ModuleRoot like File.
+// It returns "<caller unavailable>" if no caller information is available.
+func (e *Checkpoint) Location() string {
+	if !e.callerOk {
+		return callerUnavailable
+	}
+	return e.File() + LineSeparator + strconv.Itoa(e.line)
+}
+
+// Apply applies the metadata options (see Annotate) to this Checkpoint in place.
+// All other options and the default options (see SetDefaultOptions) are ignored.
+// It is mainly intended to be used by PostOptions.
+func (e *Checkpoint) Apply(options ...Option) {
+	o := collectOptions(options)
+	o.annotate(e)
+}
+
+// Message returns only the message of the error describing this Checkpoint
+// without the caller information and without the previous errors.
+// It returns an empty string if the Checkpoint has no describing error (e.g. Wrap(prev, nil)).
+// If ReplaceMessage was used, the replacement is returned instead of the message of the describing error.
+// Matches of Redact patterns are already replaced and the message is shortened according to Truncate.
+func (e *Checkpoint) Message() string {
+	message := e.message
+	if message == "" {
+		if e.err == nil {
+			return ""
+		}
+		message = e.err.Error()
+	}
+	return e.truncate(e.redact(message))
+}
+
+// renderedMessage returns the message used when rendering the Checkpoint.
+// In contrast to Message it renders a missing describing error as "<nil>".
+func (e *Checkpoint) renderedMessage() string {
+	if e.err == nil && e.message == "" {
+		return fmt.Sprint(nil)
+	}
+	return e.Message()
+}
+
+// Time returns the time at which the Checkpoint was created if WithTime was used.
+// The monotonic clock reading is preserved, so durations between the Checkpoints
+// of a chain can be calculated precisely using Sub.
+// It returns the zero time if WithTime was not used.
+func (e *Checkpoint) Time() time.Time {
+	return e.time
+}
+
+// PC returns the program counter of the location the Checkpoint was created at,
+// as returned by runtime.Callers.
+// It can be passed to runtime.CallersFrames for custom symbolization
+// or used to identify identical call sites.
+// It is 0 if no caller information is available.
+func (e *Checkpoint) PC() uintptr {
+	return e.pc
+}
+
+// Func returns the short, package qualified name of the function in which the Checkpoint
+// was created (e.g. "main.main").
+// It is empty if no caller information is available.
+func (e *Checkpoint) Func() string {
+	return e.fn
+}
+
+// Package returns the import path of the package in which the Checkpoint was created
+// (e.g. "github.com/aligator/checkpoint"), which allows grouping errors by their owning package.
+// It is captured together with the other caller information and can be rendered using SetTemplate.
+// It is empty if no caller information is available.
+func (e *Checkpoint) Package() string {
+	return e.pkg
+}
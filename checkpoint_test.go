@@ -0,0 +1,75 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNestedCheckpointsRenderNoUnknownFile(t *testing.T) {
+	err := From(errors.New("root"))
+	err = Wrap(err, errors.New("middle"))
+	err = Wrap(err, errors.New("top"))
+
+	got := err.Error()
+	if strings.Contains(got, "File: unknown") {
+		t.Errorf("Error() contains a bogus \"File: unknown\" entry:\n%s", got)
+	}
+	if n := strings.Count(got, FilePrefix); n != 3 {
+		t.Errorf("Error() renders %d file lines, want 3:\n%s", n, got)
+	}
+	if n := strings.Count(got, "\n"+Indent+Indent); n != 0 {
+		t.Errorf("Error() indents nested checkpoints twice:\n%s", got)
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	prev := errors.New("prev")
+	inner := errors.New("inner")
+
+	err := Wrapf(prev, "loading %s: %w", "config", inner)
+	if !errors.Is(err, prev) {
+		t.Errorf("errors.Is(err, prev) = false, want true")
+	}
+	if !errors.Is(err, inner) {
+		t.Errorf("errors.Is(err, inner) = false, want true as %%w is honored")
+	}
+
+	c, ok := asCheckpoint(err)
+	if !ok {
+		t.Fatalf("Wrapf returned %T, want *Checkpoint", err)
+	}
+	if got, want := c.Message(), "loading config: inner"; got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+	if got := c.File(); got != "checkpoint_test.go" {
+		t.Errorf("File() = %q, want the file of the Wrapf call", got)
+	}
+
+	if err := Wrapf(nil, "loading %s", "config"); err != nil {
+		t.Errorf("Wrapf(nil, ...) = %v, want nil", err)
+	}
+}
+
+func TestNoTrailingNewline(t *testing.T) {
+	cp := From(errors.New("failed"))
+	if strings.HasSuffix(cp.Error(), "\n") {
+		t.Errorf("Error() of a bare From ends with a line break: %q", cp.Error())
+	}
+}
+
+func TestAsFindsErrorWrappedByDescribingError(t *testing.T) {
+	inner := &os.PathError{Op: "open", Path: "config.yaml", Err: os.ErrNotExist}
+
+	err := Wrap(errors.New("prev"), fmt.Errorf("load config: %w", inner))
+
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("errors.As(err, *os.PathError) = false, want true")
+	}
+	if pathErr != inner {
+		t.Errorf("errors.As found %v, want %v", pathErr, inner)
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/aligator/checkpoint/checkpointgrpc
+
+go 1.23
+
+require (
+	github.com/aligator/checkpoint v0.2.0
+	google.golang.org/grpc v1.66.0
+)
+
+require (
+	golang.org/x/sys v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+)
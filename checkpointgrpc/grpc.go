@@ -0,0 +1,88 @@
+// Package checkpointgrpc converts checkpoint chains into gRPC statuses.
+package checkpointgrpc
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/aligator/checkpoint"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Codes maps codes attached by checkpoint.WithCode to gRPC codes.
+// Codes which are not contained are parsed as gRPC code name (e.g. "NOT_FOUND") or number
+// and otherwise result in codes.Unknown.
+// Like the configuration of the checkpoint package, it should only be modified during initialization.
+var Codes = map[string]codes.Code{}
+
+// Code returns the gRPC code for the outermost code attached by checkpoint.WithCode in the chain of err.
+// It returns codes.OK for a nil error and codes.Unknown if no code can be found.
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	code, ok := checkpoint.Code(err)
+	if !ok {
+		return codes.Unknown
+	}
+
+	if c, ok := Codes[code]; ok {
+		return c
+	}
+
+	var c codes.Code
+	if err := c.UnmarshalJSON([]byte(strconv.Quote(code))); err == nil {
+		return c
+	}
+	if err := c.UnmarshalJSON([]byte(code)); err == nil {
+		return c
+	}
+	return codes.Unknown
+}
+
+// Status converts err into a gRPC status.
+// The code is determined by Code and the message is the Message of the outermost Checkpoint.
+// If err contains no Checkpoint, err.Error() is used as message.
+// It returns nil for a nil error.
+func Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	var c *checkpoint.Checkpoint
+	if errors.As(err, &c) && c.Message() != "" {
+		message = c.Message()
+	}
+	return status.New(Code(err), message)
+}
+
+// Error wraps err so that it implements GRPCStatus() and is therefore recognized by status.FromError
+// and can be returned directly from gRPC handlers.
+// The wrapped error can still be inspected by errors.Is and errors.As.
+// It returns nil for a nil error.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return statusError{err: err}
+}
+
+type statusError struct {
+	err error
+}
+
+func (e statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e statusError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus implements the interface used by status.FromError.
+func (e statusError) GRPCStatus() *status.Status {
+	return Status(e.err)
+}
@@ -0,0 +1,10 @@
+module github.com/aligator/checkpoint/checkpointlogrus
+
+go 1.23
+
+require (
+	github.com/aligator/checkpoint v0.2.0
+	github.com/sirupsen/logrus v1.9.3
+)
+
+require golang.org/x/sys v0.21.0 // indirect
@@ -0,0 +1,64 @@
+// Package checkpointlogrus converts checkpoint chains into fields for github.com/sirupsen/logrus.
+package checkpointlogrus
+
+import (
+	"errors"
+
+	"github.com/aligator/checkpoint"
+	"github.com/sirupsen/logrus"
+)
+
+// Fields converts err into logrus.Fields:
+//
+//	log.WithFields(checkpointlogrus.Fields(err)).Error("request failed")
+//
+// The keys mirror checkpoint.Checkpoint.MarshalJSON, prefixed by "error": the message of the outermost
+// Checkpoint as "error", its caller information as "error.file", "error.line" and "error.func",
+// its metadata as "error.code", "error.severity" and "error.fields", and all layers of the chain
+// as "error.chain" using the same structure as the "frames" of MarshalJSON.
+// Keys without a value are omitted.
+// If err contains no Checkpoint, only "error" is set to err.Error().
+// It returns nil if err is nil.
+func Fields(err error) logrus.Fields {
+	if err == nil {
+		return nil
+	}
+
+	var c *checkpoint.Checkpoint
+	if !errors.As(err, &c) {
+		return logrus.Fields{"error": err.Error()}
+	}
+
+	fields := logrus.Fields{"error": c.Message()}
+	if c.File() != "" {
+		fields["error.file"] = c.File()
+		fields["error.line"] = c.Line()
+		fields["error.func"] = c.Func()
+	}
+	if c.Code() != "" {
+		fields["error.code"] = c.Code()
+	}
+	if c.Severity() != 0 {
+		fields["error.severity"] = c.Severity().String()
+	}
+	if len(c.Fields()) > 0 {
+		fields["error.fields"] = c.Fields()
+	}
+
+	frames := c.Frames()
+	chain := make([]map[string]interface{}, len(frames))
+	for i, frame := range frames {
+		layer := map[string]interface{}{"message": frame.Message}
+		if frame.Branch != "" {
+			layer["branch"] = frame.Branch
+		}
+		if frame.File != "" {
+			layer["file"] = frame.File
+			layer["line"] = frame.Line
+			layer["func"] = frame.Func
+		}
+		chain[i] = layer
+	}
+	fields["error.chain"] = chain
+	return fields
+}
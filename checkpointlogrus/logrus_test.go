@@ -0,0 +1,20 @@
+package checkpointlogrus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aligator/checkpoint"
+)
+
+func TestFieldsOfBranches(t *testing.T) {
+	err := checkpoint.WrapMany(errors.New("top"), checkpoint.From(errors.New("x")), errors.New("y"))
+
+	chain, _ := Fields(err)["error.chain"].([]map[string]interface{})
+	if len(chain) != 3 {
+		t.Fatalf("Fields() error.chain = %v, want 3 layers", chain)
+	}
+	if _, ok := chain[0]["branch"]; ok || chain[1]["branch"] != "0" || chain[2]["branch"] != "1" || chain[2]["message"] != "y" {
+		t.Errorf("Fields() error.chain = %v, want the branches of the layers", chain)
+	}
+}
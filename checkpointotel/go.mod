@@ -0,0 +1,9 @@
+module github.com/aligator/checkpoint/checkpointotel
+
+go 1.23
+
+require (
+	github.com/aligator/checkpoint v0.2.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
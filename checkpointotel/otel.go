@@ -0,0 +1,57 @@
+// Package checkpointotel connects checkpoint chains with OpenTelemetry traces.
+package checkpointotel
+
+import (
+	"context"
+
+	"github.com/aligator/checkpoint"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordSpan returns an Option which records each created Checkpoint as error event of the span
+// active in ctx using span.RecordError:
+//
+//	return checkpoint.From(err, checkpointotel.RecordSpan(ctx))
+//
+// Besides the error itself, the event contains the message, file, line and function of the Checkpoint
+// as attributes "checkpoint.message", "checkpoint.file", "checkpoint.line" and "checkpoint.func".
+// Nothing is recorded if no span is recording in ctx.
+func RecordSpan(ctx context.Context) checkpoint.PostOption {
+	return func(c *checkpoint.Checkpoint) {
+		span := trace.SpanFromContext(ctx)
+		if !span.IsRecording() {
+			return
+		}
+
+		attributes := []attribute.KeyValue{
+			attribute.String("checkpoint.message", c.Message()),
+		}
+		if c.File() != "" {
+			attributes = append(attributes,
+				attribute.String("checkpoint.file", c.File()),
+				attribute.Int("checkpoint.line", c.Line()),
+				attribute.String("checkpoint.func", c.Func()),
+			)
+		}
+		span.RecordError(c, trace.WithAttributes(attributes...))
+	}
+}
+
+// ContextFields extracts the trace and span ID of the span in ctx as the fields "trace_id" and "span_id".
+// It can be registered by checkpoint.SetContextFields to attach them to all Checkpoints created using
+// checkpoint.WithContext:
+//
+//	checkpoint.SetContextFields(checkpointotel.ContextFields)
+//
+// It returns nil if ctx contains no valid span context.
+func ContextFields(ctx context.Context) map[string]interface{} {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return map[string]interface{}{
+		"trace_id": spanContext.TraceID().String(),
+		"span_id":  spanContext.SpanID().String(),
+	}
+}
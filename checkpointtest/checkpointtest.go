@@ -0,0 +1,96 @@
+// Package checkpointtest provides helpers for testing code which returns checkpoint chains.
+package checkpointtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aligator/checkpoint"
+)
+
+// AssertChain checks that err is a checkpoint chain whose layers have the expected messages,
+// ordered from the outermost to the innermost as returned by Checkpoint.Frames:
+//
+//	checkpointtest.AssertChain(t, err, "loading config failed", "open config.yml: no such file or directory")
+//
+// A final error in the chain which is no Checkpoint is also a layer.
+// On failure, the test is marked as failed and the whole rendered chain is logged.
+func AssertChain(t testing.TB, err error, expectedMessages ...string) {
+	t.Helper()
+
+	c, ok := outermost(t, err)
+	if !ok {
+		return
+	}
+
+	frames := c.Frames()
+	messages := make([]string, len(frames))
+	for i, frame := range frames {
+		messages[i] = frame.Message
+	}
+
+	if len(messages) != len(expectedMessages) {
+		t.Errorf("expected a chain of %d layers but got %d\nexpected: %q\nactual:   %q\nchain:\n%s",
+			len(expectedMessages), len(messages), expectedMessages, messages, c)
+		return
+	}
+	for i := range messages {
+		if messages[i] != expectedMessages[i] {
+			t.Errorf("layer %d: expected message %q but got %q\nchain:\n%s", i, expectedMessages[i], messages[i], c)
+		}
+	}
+}
+
+// AssertFile checks that the outermost Checkpoint of err was created in file.
+// The file matches if it is equal to Checkpoint.File or if it forms the last path elements of it,
+// so "config/load.go" matches a Checkpoint created with FullPath in "/src/app/config/load.go".
+// On failure, the test is marked as failed and the whole rendered chain is logged.
+func AssertFile(t testing.TB, err error, file string) {
+	t.Helper()
+	AssertFileAt(t, err, 0, file)
+}
+
+// AssertFileAt checks that the layer with the given index of the chain of err was created in file.
+// The layers are counted from the outermost as returned by Checkpoint.Frames, starting at 0,
+// so the origin of the error deep in the chain can be checked, too:
+//
+//	checkpointtest.AssertFileAt(t, err, 2, "config/load.go")
+//
+// The file matches as for AssertFile.
+// On failure, the test is marked as failed and the whole rendered chain is logged.
+func AssertFileAt(t testing.TB, err error, layer int, file string) {
+	t.Helper()
+
+	c, ok := outermost(t, err)
+	if !ok {
+		return
+	}
+
+	frames := c.Frames()
+	if layer < 0 || layer >= len(frames) {
+		t.Errorf("expected a layer %d but the chain has %d layers\nchain:\n%s", layer, len(frames), c)
+		return
+	}
+
+	actual := frames[layer].File
+	if actual == "" {
+		t.Errorf("layer %d: expected a Checkpoint created in %q but it has no file\nchain:\n%s", layer, file, c)
+		return
+	}
+	if actual != file && !strings.HasSuffix(actual, "/"+file) {
+		t.Errorf("layer %d: expected the Checkpoint to be created in %q but it was created in %q\nchain:\n%s", layer, file, actual, c)
+	}
+}
+
+// outermost returns the outermost Checkpoint of err and marks the test as failed if there is none.
+func outermost(t testing.TB, err error) (*checkpoint.Checkpoint, bool) {
+	t.Helper()
+
+	var c *checkpoint.Checkpoint
+	if !errors.As(err, &c) {
+		t.Errorf("expected a checkpoint chain but got %#v", err)
+		return nil, false
+	}
+	return c, true
+}
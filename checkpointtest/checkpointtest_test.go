@@ -0,0 +1,73 @@
+package checkpointtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aligator/checkpoint"
+)
+
+// fakeTB records the failures reported by the assertions.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// newChain returns a chain of two Checkpoints created in this file.
+func newChain() error {
+	return checkpoint.Wrap(checkpoint.From(errors.New("root")), errors.New("top"))
+}
+
+func TestAssertChain(t *testing.T) {
+	tb := &fakeTB{}
+	AssertChain(tb, newChain(), "top", "root")
+	if len(tb.errors) != 0 {
+		t.Errorf("AssertChain() failed for a matching chain: %q", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	AssertChain(tb, newChain(), "top", "other")
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], `layer 1: expected message "other" but got "root"`) {
+		t.Errorf("AssertChain() reported %q, want the mismatching layer", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	AssertChain(tb, errors.New("plain"), "plain")
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "expected a checkpoint chain") {
+		t.Errorf("AssertChain() reported %q, want a missing chain", tb.errors)
+	}
+}
+
+func TestAssertFileAt(t *testing.T) {
+	err := checkpoint.Wrap(newChain(), errors.New("outer"), checkpoint.FullPath())
+
+	tb := &fakeTB{}
+	AssertFile(tb, err, "checkpointtest/checkpointtest_test.go")
+	AssertFileAt(tb, err, 2, "checkpointtest_test.go")
+	if len(tb.errors) != 0 {
+		t.Errorf("AssertFileAt() failed for matching files: %q", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	AssertFileAt(tb, err, 1, "other.go")
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], `layer 1: expected the Checkpoint to be created in "other.go"`) {
+		t.Errorf("AssertFileAt() reported %q, want the mismatching file", tb.errors)
+	}
+	if !strings.Contains(tb.errors[0], "chain:\n") {
+		t.Errorf("AssertFileAt() did not log the chain: %q", tb.errors[0])
+	}
+
+	tb = &fakeTB{}
+	AssertFileAt(tb, err, 3, "checkpointtest_test.go")
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "expected a layer 3 but the chain has 3 layers") {
+		t.Errorf("AssertFileAt() reported %q, want the missing layer", tb.errors)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/aligator/checkpoint/checkpointzap
+
+go 1.23
+
+require (
+	github.com/aligator/checkpoint v0.2.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
@@ -0,0 +1,116 @@
+// Package checkpointzap allows logging checkpoint chains as structured objects with go.uber.org/zap.
+package checkpointzap
+
+import (
+	"errors"
+
+	"github.com/aligator/checkpoint"
+	"go.uber.org/zap/zapcore"
+)
+
+// Object returns a zapcore.ObjectMarshaler for err which can be logged using zap.Object:
+//
+//	logger.Error("request failed", zap.Object("err", checkpointzap.Object(err)))
+//
+// The structure mirrors checkpoint.Checkpoint.MarshalJSON: the fields of the outermost Checkpoint
+// ("message", "file", "line", "func", "code", "severity" and "fields"), the previous error nested as "prev"
+// (or the branches of WrapMany and Merge as the array "prevs") and the array "frames" containing all layers
+// of the chain.
+// If err contains no Checkpoint, only its "message" is logged.
+func Object(err error) zapcore.ObjectMarshaler {
+	return object{err: err, top: true}
+}
+
+type object struct {
+	err error
+
+	// top is only set for the outermost object, which contains the frames.
+	top bool
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.err == nil {
+		return nil
+	}
+
+	c, ok := o.err.(*checkpoint.Checkpoint)
+	if o.top {
+		ok = errors.As(o.err, &c)
+	}
+	if !ok || c == nil {
+		enc.AddString("message", o.err.Error())
+		return nil
+	}
+
+	enc.AddString("message", c.Message())
+	if c.File() != "" {
+		enc.AddString("file", c.File())
+		enc.AddInt("line", c.Line())
+		enc.AddString("func", c.Func())
+	}
+	if c.Code() != "" {
+		enc.AddString("code", c.Code())
+	}
+	if c.Severity() != 0 {
+		enc.AddString("severity", c.Severity().String())
+	}
+	if len(c.Fields()) > 0 {
+		if err := enc.AddReflected("fields", c.Fields()); err != nil {
+			return err
+		}
+	}
+	if prevs := c.Prevs(); prevs != nil {
+		if err := enc.AddArray("prevs", objects(prevs)); err != nil {
+			return err
+		}
+	} else if prev := c.Unwrap(); prev != nil {
+		if err := enc.AddObject("prev", object{err: prev}); err != nil {
+			return err
+		}
+	}
+	if !o.top {
+		return nil
+	}
+	return enc.AddArray("frames", frames(c.Frames()))
+}
+
+type objects []error
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (o objects) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, err := range o {
+		if err := enc.AppendObject(object{err: err}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type frames []checkpoint.Frame
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (f frames) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, frame := range f {
+		if err := enc.AppendObject(logFrame(frame)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type logFrame checkpoint.Frame
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (f logFrame) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if f.Branch != "" {
+		enc.AddString("branch", f.Branch)
+	}
+	enc.AddString("message", f.Message)
+	if f.File != "" {
+		enc.AddString("file", f.File)
+		enc.AddInt("line", f.Line)
+		enc.AddString("func", f.Func)
+	}
+	return nil
+}
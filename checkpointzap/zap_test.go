@@ -0,0 +1,30 @@
+package checkpointzap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aligator/checkpoint"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestObjectOfBranches(t *testing.T) {
+	err := checkpoint.WrapMany(errors.New("top"), checkpoint.From(errors.New("x")), errors.New("y"))
+
+	enc := zapcore.NewMapObjectEncoder()
+	if err := Object(err).MarshalLogObject(enc); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := enc.Fields["prev"]; ok {
+		t.Errorf("Object() contains prev, want prevs")
+	}
+	prevs, _ := enc.Fields["prevs"].([]interface{})
+	if len(prevs) != 2 || prevs[0].(map[string]interface{})["message"] != "x" || prevs[1].(map[string]interface{})["message"] != "y" {
+		t.Errorf("Object() prevs = %v, want both branches", enc.Fields["prevs"])
+	}
+	frames, _ := enc.Fields["frames"].([]interface{})
+	if len(frames) != 3 || frames[1].(map[string]interface{})["branch"] != "0" || frames[2].(map[string]interface{})["branch"] != "1" {
+		t.Errorf("Object() frames = %v, want the branches of the layers", enc.Fields["frames"])
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/aligator/checkpoint/checkpointzerolog
+
+go 1.23
+
+require (
+	github.com/aligator/checkpoint v0.2.0
+	github.com/rs/zerolog v1.33.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
@@ -0,0 +1,102 @@
+// Package checkpointzerolog allows logging checkpoint chains as structured objects with github.com/rs/zerolog.
+package checkpointzerolog
+
+import (
+	"errors"
+
+	"github.com/aligator/checkpoint"
+	"github.com/rs/zerolog"
+)
+
+// Object returns a zerolog.LogObjectMarshaler for err which can be logged using Event.Object:
+//
+//	log.Error().Object("err", checkpointzerolog.Object(err)).Msg("request failed")
+//
+// The structure mirrors checkpoint.Checkpoint.MarshalJSON: the fields of the outermost Checkpoint
+// ("message", "file", "line", "func", "code", "severity" and "fields"), the previous error nested as "prev"
+// (or the branches of WrapMany and Merge as the array "prevs") and the array "frames" containing all layers
+// of the chain.
+// If err contains no Checkpoint, only its "message" is logged.
+func Object(err error) zerolog.LogObjectMarshaler {
+	return object{err: err, top: true}
+}
+
+type object struct {
+	err error
+
+	// top is only set for the outermost object, which contains the frames.
+	top bool
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+func (o object) MarshalZerologObject(e *zerolog.Event) {
+	if o.err == nil {
+		return
+	}
+
+	c, ok := o.err.(*checkpoint.Checkpoint)
+	if o.top {
+		ok = errors.As(o.err, &c)
+	}
+	if !ok || c == nil {
+		e.Str("message", o.err.Error())
+		return
+	}
+
+	e.Str("message", c.Message())
+	if c.File() != "" {
+		e.Str("file", c.File())
+		e.Int("line", c.Line())
+		e.Str("func", c.Func())
+	}
+	if c.Code() != "" {
+		e.Str("code", c.Code())
+	}
+	if c.Severity() != 0 {
+		e.Str("severity", c.Severity().String())
+	}
+	if len(c.Fields()) > 0 {
+		e.Interface("fields", c.Fields())
+	}
+	if prevs := c.Prevs(); prevs != nil {
+		e.Array("prevs", objects(prevs))
+	} else if prev := c.Unwrap(); prev != nil {
+		e.Object("prev", object{err: prev})
+	}
+	if o.top {
+		e.Array("frames", frames(c.Frames()))
+	}
+}
+
+type objects []error
+
+// MarshalZerologArray implements zerolog.LogArrayMarshaler.
+func (o objects) MarshalZerologArray(a *zerolog.Array) {
+	for _, err := range o {
+		a.Object(object{err: err})
+	}
+}
+
+type frames []checkpoint.Frame
+
+// MarshalZerologArray implements zerolog.LogArrayMarshaler.
+func (f frames) MarshalZerologArray(a *zerolog.Array) {
+	for _, frame := range f {
+		a.Object(logFrame(frame))
+	}
+}
+
+type logFrame checkpoint.Frame
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+func (f logFrame) MarshalZerologObject(e *zerolog.Event) {
+	if f.Branch != "" {
+		e.Str("branch", f.Branch)
+	}
+	e.Str("message", f.Message)
+	if f.File != "" {
+		e.Str("file", f.File)
+		e.Int("line", f.Line)
+		e.Str("func", f.Func)
+	}
+}
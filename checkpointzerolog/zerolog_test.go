@@ -0,0 +1,36 @@
+package checkpointzerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aligator/checkpoint"
+	"github.com/rs/zerolog"
+)
+
+func TestObjectOfBranches(t *testing.T) {
+	err := checkpoint.WrapMany(errors.New("top"), checkpoint.From(errors.New("x")), errors.New("y"))
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	logger.Error().Object("err", Object(err)).Msg("failed")
+
+	var res struct {
+		Err struct {
+			Prev   json.RawMessage            `json:"prev"`
+			Prevs  []struct{ Message string } `json:"prevs"`
+			Frames []struct{ Branch string }  `json:"frames"`
+		} `json:"err"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Err.Prev != nil || len(res.Err.Prevs) != 2 || res.Err.Prevs[0].Message != "x" || res.Err.Prevs[1].Message != "y" {
+		t.Errorf("Object() does not contain both branches as prevs:\n%s", buf.String())
+	}
+	if f := res.Err.Frames; len(f) != 3 || f[0].Branch != "" || f[1].Branch != "0" || f[2].Branch != "1" {
+		t.Errorf("Object() does not contain the branches of the frames:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,52 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// IsTemporary reports whether any error in the chain of err reports itself as temporary
+// by implementing
+//
+//	interface{ Temporary() bool }
+//
+// as e.g. some net errors do.
+// Every layer is checked, including the errors describing the Checkpoints and all branches
+// of errors created by WrapMany or Merge.
+func IsTemporary(err error) bool {
+	found := false
+	eachLayer(err, func(err error) bool {
+		t, ok := err.(interface{ Temporary() bool })
+		found = ok && t.Temporary()
+		return !found
+	})
+	return found
+}
+
+// IsTimeout reports whether any error in the chain of err is context.DeadlineExceeded or
+// reports itself as timeout by implementing
+//
+//	interface{ Timeout() bool }
+//
+// as e.g. net.Error does.
+// Every layer is checked, including the errors describing the Checkpoints and all branches
+// of errors created by WrapMany or Merge.
+func IsTimeout(err error) bool {
+	found := false
+	eachLayer(err, func(err error) bool {
+		if err == context.DeadlineExceeded {
+			found = true
+		} else if t, ok := err.(interface{ Timeout() bool }); ok {
+			found = t.Timeout()
+		}
+		return !found
+	})
+	return found
+}
+
+// IsEOF reports whether err is or wraps io.EOF or io.ErrUnexpectedEOF according to errors.Is.
+// It gives the same result for an EOF returned bare because of IgnoreEOF and for one wrapped by Checkpoints.
+func IsEOF(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
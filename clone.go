@@ -0,0 +1,47 @@
+package checkpoint
+
+// Clone returns a deep copy of the checkpoint chain starting at this Checkpoint.
+// All Checkpoints of the chain are copied including their fields, redactions and stacks,
+// so the copy can be modified, e.g. by Apply, without affecting the original.
+// Errors which are no Checkpoints, including the errors describing the Checkpoints, are shared
+// as they are usually immutable.
+// The caller information is copied and not captured again.
+func (e *Checkpoint) Clone() *Checkpoint {
+	var seen visited
+	return e.clone(&seen)
+}
+
+// clone copies the Checkpoint and recursively its previous errors.
+// Checkpoints visited a second time in a cyclic chain are shared instead of copied.
+func (e *Checkpoint) clone(seen *visited) *Checkpoint {
+	if !seen.first(e) {
+		return e
+	}
+	c := *e
+
+	if e.fields != nil {
+		c.fields = make(map[string]interface{}, len(e.fields))
+		for key, value := range e.fields {
+			c.fields[key] = value
+		}
+	}
+	c.redactions = append(c.redactions[:0:0], e.redactions...)
+	c.stack = append(c.stack[:0:0], e.stack...)
+
+	switch prev := e.prev.(type) {
+	case *Checkpoint:
+		if prev != nil {
+			c.prev = prev.clone(seen)
+		}
+	case multiError:
+		errs := make([]error, len(prev.errs))
+		for i, err := range prev.errs {
+			if branch, ok := asCheckpoint(err); ok {
+				err = branch.clone(seen)
+			}
+			errs[i] = err
+		}
+		c.prev = multiError{errs: errs, labeled: prev.labeled}
+	}
+	return &c
+}
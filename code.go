@@ -0,0 +1,40 @@
+package checkpoint
+
+// WithCode tags the Checkpoint with a stable error code, e.g. to map errors to API responses.
+// The code can be retrieved by Checkpoint.Code or for a whole chain by the package functions Code and Codes.
+func WithCode(code string) Option {
+	return optionFunc(func(o *options) {
+		o.code = code
+	})
+}
+
+// Code returns the code attached to this Checkpoint by WithCode.
+// It is empty if no code was attached.
+func (e *Checkpoint) Code() string {
+	return e.code
+}
+
+// Code returns the outermost code attached by WithCode in the chain of err.
+// ok is false if no Checkpoint of the chain has a code.
+func Code(err error) (code string, ok bool) {
+	Walk(err, func(c *Checkpoint) bool {
+		if c.code == "" {
+			return true
+		}
+		code, ok = c.code, true
+		return false
+	})
+	return code, ok
+}
+
+// Codes returns all codes attached by WithCode in the chain of err ordered from the outermost to the innermost.
+func Codes(err error) []string {
+	var codes []string
+	Walk(err, func(c *Checkpoint) bool {
+		if c.code != "" {
+			codes = append(codes, c.code)
+		}
+		return true
+	})
+	return codes
+}
@@ -0,0 +1,34 @@
+package checkpoint
+
+import (
+	"os"
+	"strings"
+)
+
+// ANSI escape codes used by ColorString.
+const (
+	colorReset    = "\x1b[0m"
+	colorLocation = "\x1b[36m"
+	colorMessage  = "\x1b[31m"
+)
+
+// ColorString renders the checkpoint chain like Error() but highlights the caller information
+// and the messages using ANSI colors, which is useful when writing to a terminal.
+//
+// If the environment variable NO_COLOR is set to a non-empty value
+// (see https://no-color.org), no escape codes are added.
+func (e *Checkpoint) ColorString() string {
+	if os.Getenv("NO_COLOR") != "" {
+		return e.Error()
+	}
+
+	return strings.Join(e.layers(formatColorNode, formatColorForeign), "\n")
+}
+
+func formatColorNode(c *Checkpoint) string {
+	return colorLocation + c.header() + colorReset + c.renderedSource() + "\n" + Indent + colorMessage + c.renderedMessage() + colorReset
+}
+
+func formatColorForeign(err error) string {
+	return colorMessage + foreignMessage(err.Error()) + colorReset + foreignStack(err)
+}
@@ -0,0 +1,63 @@
+package checkpoint
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	contextFieldsMu sync.RWMutex
+	contextFields   func(ctx context.Context) map[string]interface{}
+)
+
+// SetContextFields registers a function extracting fields (e.g. a trace or request ID) from the contexts
+// passed to WithContext. The extracted fields are attached like WithFields, so they are included in the
+// output of Error() and MarshalJSON.
+// Passing nil disables the extraction.
+//
+// It is safe to call SetContextFields concurrently with creating Checkpoints,
+// but it is intended to be called once during initialization.
+func SetContextFields(fn func(ctx context.Context) map[string]interface{}) {
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+	contextFields = fn
+}
+
+// WithContext attaches ctx to the Checkpoint. It can be retrieved by Checkpoint.Context or
+// for the whole chain by Context.
+// If a function was registered by SetContextFields, the fields it extracts from ctx are attached
+// to the Checkpoint as well.
+//
+// The Checkpoint keeps a reference to ctx as long as it lives, so the values stored in ctx
+// are kept alive by the error, too.
+func WithContext(ctx context.Context) Option {
+	return optionFunc(func(o *options) {
+		o.ctx = ctx
+
+		contextFieldsMu.RLock()
+		fn := contextFields
+		contextFieldsMu.RUnlock()
+		if fn == nil || ctx == nil {
+			return
+		}
+		WithFields(fn(ctx)).apply(o)
+	})
+}
+
+// Context returns the context attached to this Checkpoint by WithContext.
+// It returns nil if no context was attached.
+func (e *Checkpoint) Context() context.Context {
+	return e.ctx
+}
+
+// Context returns the context attached by WithContext to the outermost Checkpoint of the chain of err
+// which has one.
+// It returns nil if no Checkpoint of the chain has a context.
+func Context(err error) context.Context {
+	var ctx context.Context
+	Walk(err, func(c *Checkpoint) bool {
+		ctx = c.ctx
+		return ctx == nil
+	})
+	return ctx
+}
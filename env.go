@@ -0,0 +1,44 @@
+package checkpoint
+
+import (
+	"fmt"
+	"os"
+)
+
+// pathModeEnv is the environment variable selecting the default path mode of all Checkpoints.
+const pathModeEnv = "CHECKPOINT_PATH_MODE"
+
+// defaultPath is the path mode used if no FullPath, ShortPath or BasePath option is passed.
+var defaultPath = pathModeFromEnv()
+
+// pathModeFromEnv reads the default path mode from the environment variable CHECKPOINT_PATH_MODE
+// when the package is initialized, so the same binary can e.g. use full paths during development
+// and only file names in production without any code changes:
+//
+//   - "full" records the complete path like FullPath,
+//   - "short" records the directory and the file name like ShortPath,
+//   - "base" records only the file name like BasePath,
+//   - "relative" or an empty value record the path relative to the working directory, which is the default.
+//
+// Options passed to the individual calls or to SetDefaultOptions override this default.
+// Invalid values fall back to "base" and print a warning to stderr.
+func pathModeFromEnv() pathMode {
+	value, ok := os.LookupEnv(pathModeEnv)
+	if !ok {
+		return pathRelative
+	}
+
+	switch value {
+	case "", "relative":
+		return pathRelative
+	case "full":
+		return pathFull
+	case "short":
+		return pathShort
+	case "base":
+		return pathBase
+	}
+
+	fmt.Fprintf(os.Stderr, "checkpoint: invalid %s %q, using \"base\"\n", pathModeEnv, value)
+	return pathBase
+}
@@ -0,0 +1,171 @@
+package checkpoint
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// EqualOption configures the comparison done by Checkpoint.Equal and Diff.
+type EqualOption func(o *equalOptions)
+
+type equalOptions struct {
+	ignoreCaller bool
+	ignoreLines  bool
+}
+
+// IgnoreCaller makes Checkpoint.Equal and Diff ignore the caller information (file, line and function)
+// so that only the errors of the chains are compared.
+func IgnoreCaller() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreCaller = true
+	}
+}
+
+// IgnoreLines makes Checkpoint.Equal and Diff ignore the lines of the caller information,
+// so that the comparison does not break if code is added above the locations.
+func IgnoreLines() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreLines = true
+	}
+}
+
+// Equal reports whether the chain of e represents the same logical error as the chain of other.
+// Two Checkpoints are equal if their describing errors match by errors.Is, they were created at the
+// same location and their previous errors are equal as well.
+// The branches of errors created by WrapMany or Merge are compared pairwise in order.
+// Previous errors which are no Checkpoints are compared by errors.Is.
+// A nil Checkpoint is only equal to another nil Checkpoint.
+//
+// In tests the locations usually differ, so IgnoreCaller can be used to only compare the errors.
+func (e *Checkpoint) Equal(other *Checkpoint, opts ...EqualOption) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+
+	var o equalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var seen, otherSeen visited
+	return e.equal(other, o, &seen, &otherSeen)
+}
+
+// equal implements Equal. seen and otherSeen record the Checkpoints compared so far
+// to stop at cycles; two chains are equal if they enter a cycle at the same layer.
+func (e *Checkpoint) equal(other *Checkpoint, o equalOptions, seen, otherSeen *visited) bool {
+	first, otherFirst := seen.first(e), otherSeen.first(other)
+	if !first || !otherFirst {
+		return first == otherFirst
+	}
+
+	if !errors.Is(e.err, other.err) {
+		return false
+	}
+
+	if !o.ignoreCaller {
+		if e.callerOk != other.callerOk || e.file != other.file || e.fn != other.fn {
+			return false
+		}
+		if !o.ignoreLines && e.line != other.line {
+			return false
+		}
+	}
+
+	return equalPrev(e.prev, other.prev, o, seen, otherSeen)
+}
+
+// equalPrev compares the previous errors prev and other of two Checkpoints as described by Equal.
+func equalPrev(prev, other error, o equalOptions, seen, otherSeen *visited) bool {
+	c, ok := asCheckpoint(prev)
+	otherC, otherOk := asCheckpoint(other)
+	if ok || otherOk {
+		return ok && otherOk && c.equal(otherC, o, seen, otherSeen)
+	}
+
+	m, ok := prev.(multiError)
+	otherM, otherOk := other.(multiError)
+	if ok || otherOk {
+		if !ok || !otherOk || len(m.errs) != len(otherM.errs) {
+			return false
+		}
+		for i := range m.errs {
+			if !equalPrev(m.errs[i], otherM.errs[i], o, seen, otherSeen) {
+				return false
+			}
+		}
+		return true
+	}
+	return errors.Is(prev, other)
+}
+
+// Diff compares the chains of a and b layer by layer using their Frames, e.g. to compare errors
+// with golden values in tests.
+// It returns an empty string if both chains have the same messages and locations in the same order.
+// Otherwise it returns a human readable description of each differing layer:
+//
+//	layer 1:
+//	- foo.go:10 (pkg.load): open failed
+//	+ foo.go:12 (pkg.load): open failed
+//
+// Layers only present in one chain are marked as "<missing>" in the other.
+// IgnoreCaller compares only the messages and IgnoreLines ignores the lines.
+// Errors which are no Checkpoints are compared as a single layer with their message.
+func Diff(a, b error, opts ...EqualOption) string {
+	var o equalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	framesA, framesB := diffFrames(a), diffFrames(b)
+	var res strings.Builder
+	for i := 0; i < len(framesA) || i < len(framesB); i++ {
+		lineA, lineB := "<missing>", "<missing>"
+		var frameA, frameB Frame
+		if i < len(framesA) {
+			frameA = framesA[i]
+			lineA = frameA.String()
+		}
+		if i < len(framesB) {
+			frameB = framesB[i]
+			lineB = frameB.String()
+		}
+		if i < len(framesA) && i < len(framesB) && o.sameFrame(frameA, frameB) {
+			continue
+		}
+
+		res.WriteString("layer ")
+		res.WriteString(strconv.Itoa(i))
+		res.WriteString(":\n- ")
+		res.WriteString(lineA)
+		res.WriteString("\n+ ")
+		res.WriteString(lineB)
+		res.WriteString("\n")
+	}
+	return res.String()
+}
+
+// diffFrames returns the layers of err compared by Diff.
+func diffFrames(err error) []Frame {
+	if err == nil {
+		return nil
+	}
+	if c, ok := asCheckpoint(err); ok {
+		return c.Frames()
+	}
+	return []Frame{{Message: err.Error()}}
+}
+
+// sameFrame reports whether a and b are equal according to the options.
+func (o equalOptions) sameFrame(a, b Frame) bool {
+	if a.Message != b.Message || a.Branch != b.Branch {
+		return false
+	}
+	if o.ignoreCaller {
+		return true
+	}
+	if a.File != b.File || a.Func != b.Func {
+		return false
+	}
+	return o.ignoreLines || a.Line == b.Line
+}
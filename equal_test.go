@@ -0,0 +1,40 @@
+package checkpoint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	newMerge := func() *Checkpoint {
+		c, _ := asCheckpoint(Merge(From(errA), errB))
+		return c
+	}
+	cyclic := func() *Checkpoint {
+		c, _ := asCheckpoint(From(errA))
+		c.prev = c
+		return c
+	}
+	c, _ := asCheckpoint(From(errA))
+
+	tests := []struct {
+		name string
+		a, b *Checkpoint
+		want bool
+	}{
+		{name: "nil", a: c, b: nil, want: false},
+		{name: "both nil", a: nil, b: nil, want: true},
+		{name: "merge", a: newMerge(), b: newMerge(), want: true},
+		{name: "merge and single", a: newMerge(), b: c, want: false},
+		{name: "cycle", a: cyclic(), b: cyclic(), want: true},
+		{name: "cycle and single", a: cyclic(), b: c, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b, IgnoreCaller()); got != tt.want {
+				t.Errorf("Equal() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
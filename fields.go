@@ -0,0 +1,60 @@
+package checkpoint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WithField attaches the key-value pair to the Checkpoint.
+// It can be retrieved by Checkpoint.Fields and is included in the output of Error() and MarshalJSON.
+// If the same key is set several times, the last value wins.
+func WithField(key string, value interface{}) Option {
+	return optionFunc(func(o *options) {
+		if o.fields == nil {
+			o.fields = make(map[string]interface{})
+		}
+		o.fields[key] = value
+	})
+}
+
+// WithFields attaches all key-value pairs of fields to the Checkpoint.
+// It works like calling WithField for each of them.
+func WithFields(fields map[string]interface{}) Option {
+	return optionFunc(func(o *options) {
+		if o.fields == nil {
+			o.fields = make(map[string]interface{}, len(fields))
+		}
+		for key, value := range fields {
+			o.fields[key] = value
+		}
+	})
+}
+
+// Fields returns the key-value pairs attached to this Checkpoint by WithField and WithFields.
+// Fields of previous Checkpoints are not included.
+// It returns nil if no fields were attached.
+// The returned map must not be modified.
+func (e *Checkpoint) Fields() map[string]interface{} {
+	return e.fields
+}
+
+// formatFields renders the fields sorted by key as "{key1=value1, key2=value2}".
+func formatFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("{")
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s=%v", key, fields[key])
+	}
+	b.WriteString("}")
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package checkpoint
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// Fingerprint returns a short hex string of fixed length identifying the code path of the chain of err,
+// e.g. to group or deduplicate alerts.
+// It hashes the package, function, line and code (see WithCode) of every Checkpoint of the chain in order
+// and deliberately ignores their messages, so two errors produced by the same call sites result
+// in the same fingerprint even if the wrapped values differ.
+// As the file paths are not used, the fingerprint neither depends on the working directory nor on the path mode,
+// so it is the same for all deployments of the same binary.
+//
+// Errors which are no Checkpoints are not part of the fingerprint, as long as the chain contains a Checkpoint.
+// As there is no code path to identify otherwise, the message is hashed instead for Checkpoints without
+// caller information and for errors whose chain contains no Checkpoint at all.
+func Fingerprint(err error) string {
+	h := fnv.New64a()
+	found := false
+	Walk(err, func(c *Checkpoint) bool {
+		found = true
+		if c.callerOk {
+			_, _ = h.Write([]byte(c.pkg))
+			_, _ = h.Write([]byte{0})
+			_, _ = h.Write([]byte(c.fn))
+			_, _ = h.Write([]byte{':'})
+			_, _ = h.Write([]byte(strconv.Itoa(c.line)))
+		} else {
+			_, _ = h.Write([]byte(c.Message()))
+		}
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(c.code))
+		_, _ = h.Write([]byte{0})
+		return true
+	})
+	if !found && err != nil {
+		_, _ = h.Write([]byte(err.Error()))
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
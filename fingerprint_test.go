@@ -0,0 +1,37 @@
+package checkpoint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	if Fingerprint(errors.New("a")) == Fingerprint(errors.New("b")) {
+		t.Errorf("Fingerprint() is the same for distinct errors without Checkpoint")
+	}
+
+	newErr := func(message string, options ...Option) error {
+		return From(errors.New(message), options...)
+	}
+	if Fingerprint(newErr("a")) != Fingerprint(newErr("b")) {
+		t.Errorf("Fingerprint() differs for errors created at the same location")
+	}
+	if Fingerprint(newErr("a")) != Fingerprint(newErr("a", FullPath())) {
+		t.Errorf("Fingerprint() depends on the path mode")
+	}
+}
+
+func TestSeenDistinctErrors(t *testing.T) {
+	defer SetSeenCache(0, 0)
+	SetSeenCache(0, 0)
+
+	if Seen(errors.New("a")) {
+		t.Errorf("Seen() = true for the first error")
+	}
+	if Seen(errors.New("b")) {
+		t.Errorf("Seen() = true for a distinct error")
+	}
+	if !Seen(errors.New("a")) {
+		t.Errorf("Seen() = false for a repeated error")
+	}
+}
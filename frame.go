@@ -0,0 +1,179 @@
+package checkpoint
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Frame describes a single layer of a checkpoint chain.
+type Frame struct {
+	// File is the source file in which the Checkpoint was created.
+	// It is empty for errors which are no Checkpoints and if no caller information is available.
+	File string
+	// Line is the line in File in which the Checkpoint was created.
+	Line int
+	// Func is the short function name in which the Checkpoint was created.
+	Func string
+	// Message is the message of the error of this layer without the previous errors
+	// (for errors which are no Checkpoints it is just err.Error()).
+	Message string
+	// Branch identifies the branch of an error created by WrapMany or Merge the layer belongs to
+	// by the indices of the branches separated by dots, e.g. "1" for the second branch or "0.1"
+	// for the second branch inside of the first branch.
+	// It is empty for the layers which are part of no branch.
+	Branch string
+}
+
+// Frames returns all layers of the checkpoint chain ordered from the outermost to the innermost.
+// An error in the chain which is no Checkpoint is represented by a Frame with only the Message set.
+// As such an error is rendered as a whole, it ends the chain.
+// The branches of errors created by WrapMany or Merge are visited depth first like by Walk, and their
+// layers are marked by Frame.Branch.
+// If the chain is cyclic, it ends with a Frame with the Message "(cycle detected)".
+func (e *Checkpoint) Frames() []Frame {
+	return slices.Collect(e.All())
+}
+
+// All returns an iterator over the same layers as Frames without allocating a slice:
+//
+//	for frame := range c.All() {
+//		fmt.Println(frame)
+//	}
+//
+// The walk stops as soon as the loop is left.
+func (e *Checkpoint) All() iter.Seq[Frame] {
+	return func(yield func(Frame) bool) {
+		eachChainLayer(e, func(layer chainLayer) bool {
+			frame := Frame{Message: layer.message(), Branch: layer.branch}
+			if layer.c != nil && layer.c.callerOk {
+				frame.File = layer.c.File()
+				frame.Line = layer.c.line
+				frame.Func = layer.c.fn
+			}
+			return yield(frame)
+		})
+	}
+}
+
+// chainLayer is a single layer of a checkpoint chain visited by eachChainLayer.
+type chainLayer struct {
+	// c is the Checkpoint of the layer. It is nil if the layer is no Checkpoint or marks a cycle.
+	c *Checkpoint
+	// foreign is the error of a layer which is no Checkpoint.
+	foreign error
+	// branch is the branch of the layer as described by Frame.Branch.
+	branch string
+}
+
+// message returns the message of the layer, which is cycleMarker for a layer marking a cycle.
+func (l chainLayer) message() string {
+	switch {
+	case l.c != nil:
+		return l.c.Message()
+	case l.foreign != nil:
+		return l.foreign.Error()
+	}
+	return cycleMarker
+}
+
+// eachChainLayer calls fn for every layer of the chain of err as described by Checkpoint.Frames
+// until fn returns false.
+// A branch ends with an error which is no Checkpoint or with a layer marking a cycle.
+func eachChainLayer(err error, fn func(layer chainLayer) bool) {
+	var seen visited
+	steps := 0
+	var walk func(err error, branch string) bool
+	walk = func(err error, branch string) bool {
+		for ; err != nil && steps < maxChainLength; steps++ {
+			if m, ok := err.(multiError); ok {
+				for i, branchErr := range m.errs {
+					if !walk(branchErr, subBranch(branch, i)) {
+						return false
+					}
+				}
+				return true
+			}
+
+			c, ok := asCheckpoint(err)
+			if !ok {
+				return fn(chainLayer{foreign: err, branch: branch})
+			}
+			if !seen.first(c) {
+				return fn(chainLayer{branch: branch})
+			}
+			if !fn(chainLayer{c: c, branch: branch}) {
+				return false
+			}
+			err = c.prev
+		}
+		return true
+	}
+	walk(err, "")
+}
+
+// subBranch returns the branch with index i inside of branch.
+func subBranch(branch string, i int) string {
+	if branch == "" {
+		return strconv.Itoa(i)
+	}
+	return branch + "." + strconv.Itoa(i)
+}
+
+// AllErrors returns an iterator over the chain starting at this Checkpoint as followed by errors.Unwrap,
+// ordered from the outermost to the innermost. In contrast to All, errors which are no Checkpoints
+// do not end the chain as long as they support Unwrap.
+// The walk stops as soon as the loop is left or a Checkpoint would be visited a second time.
+func (e *Checkpoint) AllErrors() iter.Seq[error] {
+	return func(yield func(error) bool) {
+		var seen visited
+		var err error = e
+		for i := 0; err != nil && i < maxChainLength; i++ {
+			if c, ok := asCheckpoint(err); ok && !seen.first(c) {
+				return
+			}
+			if !yield(err) {
+				return
+			}
+			err = errors.Unwrap(err)
+		}
+	}
+}
+
+// String implements fmt.Stringer and renders the Frame as "file:line (func): message",
+// using the same LineSeparator as Error().
+// The function is omitted if it is unknown, and the message if it is empty.
+// Frames without file, such as those of errors which are no Checkpoints, only render the message.
+// Frames of a branch are prefixed by the branch, e.g. "[1] ".
+func (f Frame) String() string {
+	if f.File == "" {
+		return f.branchLabel() + f.Message
+	}
+
+	var b strings.Builder
+	b.WriteString(f.branchLabel())
+	b.WriteString(f.File)
+	b.WriteString(LineSeparator)
+	b.WriteString(strconv.Itoa(f.Line))
+	if f.Func != "" {
+		b.WriteString(" (")
+		b.WriteString(f.Func)
+		b.WriteString(")")
+	}
+	if f.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(f.Message)
+	}
+	return b.String()
+}
+
+// branchLabel returns the prefix marking the branch of the Frame, e.g. "[1] ".
+// It is empty for Frames which are part of no branch.
+func (f Frame) branchLabel() string {
+	if f.Branch == "" {
+		return ""
+	}
+	return "[" + f.Branch + "] "
+}
@@ -1 +1,3 @@
-module "github.com/aligator/checkpoint"
\ No newline at end of file
+module github.com/aligator/checkpoint
+
+go 1.23
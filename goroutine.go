@@ -0,0 +1,42 @@
+package checkpoint
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// WithGoroutineID records the ID of the goroutine creating the Checkpoint.
+// It can be retrieved by Checkpoint.GoroutineID and is also included in the output of Error().
+//
+// The Go runtime does not expose goroutine IDs officially, so the ID is parsed from the
+// header of runtime.Stack. It should only be used to correlate errors while debugging.
+func WithGoroutineID() Option {
+	return optionFunc(func(o *options) {
+		o.goroutine = true
+	})
+}
+
+// GoroutineID returns the ID of the goroutine which created the Checkpoint if WithGoroutineID was used.
+// It returns 0 if WithGoroutineID was not used.
+func (e *Checkpoint) GoroutineID() uint64 {
+	return e.goroutine
+}
+
+// goroutineID parses the ID of the current goroutine from the header of runtime.Stack,
+// which has the form "goroutine 18 [running]:".
+// It returns 0 if the header cannot be parsed.
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+
+	id, err := strconv.ParseUint(string(header), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
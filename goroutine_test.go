@@ -0,0 +1,24 @@
+package checkpoint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGoroutineIDDiffers(t *testing.T) {
+	ids := make(chan uint64, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			c, _ := asCheckpoint(From(errors.New("failed"), WithGoroutineID()))
+			ids <- c.GoroutineID()
+		}()
+	}
+
+	first, second := <-ids, <-ids
+	if first == 0 || second == 0 {
+		t.Fatalf("GoroutineID() = %d and %d, want non zero IDs", first, second)
+	}
+	if first == second {
+		t.Errorf("GoroutineID() = %d for both goroutines, want different IDs", first)
+	}
+}
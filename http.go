@@ -0,0 +1,51 @@
+package checkpoint
+
+import "net/http"
+
+// WithStatus attaches an HTTP status code to the Checkpoint.
+// It can be retrieved for a whole chain by HTTPStatus.
+func WithStatus(code int) Option {
+	return optionFunc(func(o *options) {
+		o.status = code
+	})
+}
+
+// Status returns the HTTP status code attached to this Checkpoint by WithStatus.
+// It is 0 if no status was attached.
+func (e *Checkpoint) Status() int {
+	return e.status
+}
+
+// HTTPStatus returns the outermost HTTP status code attached by WithStatus in the chain of err.
+// If no Checkpoint of the chain has a status, the status implied by the outermost category
+// set by WithCategory is returned (see Category.Status).
+// It returns http.StatusInternalServerError if no Checkpoint of the chain has a status or a category.
+//
+// It can be used to translate errors to responses at the edge of a service:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		if err := serve(r); err != nil {
+//			status := checkpoint.HTTPStatus(err)
+//			http.Error(w, http.StatusText(status), status)
+//			return
+//		}
+//		w.WriteHeader(http.StatusNoContent)
+//	}
+func HTTPStatus(err error) int {
+	status := 0
+	Walk(err, func(c *Checkpoint) bool {
+		if c.status == 0 {
+			return true
+		}
+		status = c.status
+		return false
+	})
+	if status != 0 {
+		return status
+	}
+
+	if category, ok := CategoryOf(err); ok {
+		return category.Status()
+	}
+	return http.StatusInternalServerError
+}
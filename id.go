@@ -0,0 +1,53 @@
+package checkpoint
+
+import "math/rand"
+
+// idAlphabet contains the characters used for the IDs generated by WithID.
+const idAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// idLength is the number of characters of the IDs generated by WithID.
+const idLength = 8
+
+// WithID attaches a short random ID such as "k3x9q2ma" to the Checkpoint, so a specific error can be referenced,
+// e.g. when users report it. It can be retrieved by Checkpoint.ID or for a whole chain by ID
+// and is also rendered in front of the caller information by Error():
+//
+//	[k3x9q2ma] File: foo.go:10 (pkg.function)
+//
+// The IDs are cheap to generate and unique enough to correlate log lines, but they are not cryptographically secure.
+func WithID() Option {
+	return optionFunc(func(o *options) {
+		o.id = true
+	})
+}
+
+// newID generates a random ID for WithID.
+func newID() string {
+	var id [idLength]byte
+	n := rand.Uint64()
+	for i := range id {
+		id[i] = idAlphabet[n%uint64(len(idAlphabet))]
+		n /= uint64(len(idAlphabet))
+	}
+	return string(id[:])
+}
+
+// ID returns the ID attached to this Checkpoint by WithID.
+// It is empty if WithID was not used.
+func (e *Checkpoint) ID() string {
+	return e.id
+}
+
+// ID returns the outermost ID attached by WithID in the chain of err, which can serve as reference code
+// for the whole error.
+// ok is false if no Checkpoint of the chain has an ID.
+func ID(err error) (id string, ok bool) {
+	Walk(err, func(c *Checkpoint) bool {
+		if c.id == "" {
+			return true
+		}
+		id, ok = c.id, true
+		return false
+	})
+	return id, ok
+}
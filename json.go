@@ -0,0 +1,199 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// jsonCheckpoint is the JSON representation of a single Checkpoint.
+// Errors which are no Checkpoints only set the Message.
+type jsonCheckpoint struct {
+	Message  string                 `json:"message"`
+	File     string                 `json:"file,omitempty"`
+	Line     int                    `json:"line,omitempty"`
+	Func     string                 `json:"func,omitempty"`
+	Code     string                 `json:"code,omitempty"`
+	Severity string                 `json:"severity,omitempty"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+	Prev     *jsonCheckpoint        `json:"prev,omitempty"`
+
+	// Prevs is set instead of Prev for the branches of errors created by WrapMany or Merge.
+	Prevs []*jsonCheckpoint `json:"prevs,omitempty"`
+	// Merged marks Checkpoints created by Merge, whose branches are labeled by their index.
+	Merged bool `json:"merged,omitempty"`
+
+	// Branch is only set for the frames and the lines of JSONLines, see Frame.Branch.
+	Branch string `json:"branch,omitempty"`
+
+	// Frames is only set for the outermost Checkpoint.
+	Frames []jsonCheckpoint `json:"frames,omitempty"`
+}
+
+// newJSONCheckpoint converts err and recursively all its previous errors.
+func newJSONCheckpoint(err error, seen *visited) *jsonCheckpoint {
+	c, ok := asCheckpoint(err)
+	if !ok {
+		return &jsonCheckpoint{Message: err.Error()}
+	}
+	if !seen.first(c) {
+		return &jsonCheckpoint{Message: cycleMarker}
+	}
+
+	res := newJSONNode(c)
+	if m, ok := c.prev.(multiError); ok {
+		res.Prevs = make([]*jsonCheckpoint, len(m.errs))
+		res.Merged = m.labeled
+		for i, branch := range m.errs {
+			res.Prevs[i] = newJSONCheckpoint(branch, seen)
+		}
+	} else if c.prev != nil {
+		res.Prev = newJSONCheckpoint(c.prev, seen)
+	}
+	return res
+}
+
+// frames appends all layers of j to res in the order of Checkpoint.Frames.
+func (j *jsonCheckpoint) frames(res []jsonCheckpoint, branch string) []jsonCheckpoint {
+	frame := *j
+	frame.Prev, frame.Prevs, frame.Merged, frame.Branch = nil, nil, false, branch
+	res = append(res, frame)
+
+	if j.Prev != nil {
+		return j.Prev.frames(res, branch)
+	}
+	for i, prev := range j.Prevs {
+		res = prev.frames(res, subBranch(branch, i))
+	}
+	return res
+}
+
+// newJSONNode converts only c without its previous errors.
+func newJSONNode(c *Checkpoint) *jsonCheckpoint {
+	res := &jsonCheckpoint{
+		Message:  c.Message(),
+		Code:     c.code,
+		Severity: c.severity.String(),
+		Fields:   c.fields,
+	}
+	if c.callerOk {
+		res.File = c.File()
+		res.Line = c.line
+		res.Func = c.fn
+	}
+	return res
+}
+
+// MarshalJSON implements json.Marshaler.
+// The Checkpoint is encoded as object with the fields "message", "file", "line", "func", "code", "severity"
+// and "fields" containing the fields attached by WithField.
+// The previous error is nested as "prev" using the same structure.
+// The branches of errors created by WrapMany or Merge are nested as "prevs" array instead,
+// and Checkpoints created by Merge are marked by "merged" set to true.
+// Previous errors which are no Checkpoints only contain the "message".
+// Additionally, the outermost object contains all layers flattened as "frames" array in the order of Frames,
+// where the layers of branches contain their "branch" (see Frame.Branch).
+func (e *Checkpoint) MarshalJSON() ([]byte, error) {
+	var seen visited
+	res := newJSONCheckpoint(e, &seen)
+	res.Frames = res.frames(nil, "")
+	return json.Marshal(res)
+}
+
+// jsonLine is a single line of the output of JSONLines.
+type jsonLine struct {
+	Index int `json:"index"`
+	*jsonCheckpoint
+
+	// Foreign marks errors which are no Checkpoints.
+	Foreign bool `json:"foreign,omitempty"`
+}
+
+// JSONLines renders the checkpoint chain as newline delimited JSON (NDJSON) with one object per layer,
+// in the order of Frames.
+// Each object contains the position of the layer as "index" (starting at 0 for this Checkpoint) and the same fields
+// as MarshalJSON without "prev", "prevs" and "frames". The layers of branches contain their "branch" instead.
+// An error in the chain which is no Checkpoint ends its branch with an object containing only
+// its "index", its "message", its "branch" and "foreign" set to true.
+// Each line, including the last one, is terminated by a line break.
+func (e *Checkpoint) JSONLines() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	index := 0
+	var encErr error
+	eachChainLayer(e, func(layer chainLayer) bool {
+		line := jsonLine{Index: index}
+		if layer.c != nil {
+			line.jsonCheckpoint = newJSONNode(layer.c)
+		} else {
+			line.jsonCheckpoint = &jsonCheckpoint{Message: layer.message()}
+			line.Foreign = layer.foreign != nil
+		}
+		line.Branch = layer.branch
+		index++
+
+		encErr = enc.Encode(line)
+		return encErr == nil
+	})
+	if encErr != nil {
+		return nil, encErr
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It reconstructs a Checkpoint and recursively its previous errors from the structure produced by MarshalJSON.
+// The describing errors are recreated from the messages, so their original types are lost.
+// Caller information is only available if "file" and "line" are present; the program counter and the stack
+// are never restored.
+// Previous errors which only contain a "message" are restored as plain errors instead of Checkpoints.
+// The branches of "prevs" are restored like the branches of WrapMany, or of Merge if "merged" is set.
+// The "frames" array is ignored as it can be derived from the chain.
+func (e *Checkpoint) UnmarshalJSON(data []byte) error {
+	var res jsonCheckpoint
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	*e = *res.checkpoint()
+	return nil
+}
+
+// checkpoint converts the JSON representation back to a Checkpoint.
+func (j *jsonCheckpoint) checkpoint() *Checkpoint {
+	c := &Checkpoint{
+		err:      errors.New(j.Message),
+		file:     j.File,
+		line:     j.Line,
+		fn:       j.Func,
+		callerOk: j.File != "" && j.Line != 0,
+		code:     j.Code,
+		severity: parseSeverity(j.Severity),
+		fields:   j.Fields,
+	}
+	switch {
+	case j.Prev != nil:
+		c.prev = j.Prev.error()
+	case len(j.Prevs) == 1:
+		c.prev = j.Prevs[0].error()
+	case len(j.Prevs) > 1:
+		m := multiError{errs: make([]error, len(j.Prevs)), labeled: j.Merged}
+		for i, prev := range j.Prevs {
+			m.errs[i] = prev.error()
+		}
+		c.prev = m
+		if j.Merged {
+			c.err = mergedErrors(len(j.Prevs))
+		}
+	}
+	return c
+}
+
+// error converts the JSON representation of a previous error back to an error.
+// Only errors containing more than a message become Checkpoints.
+func (j *jsonCheckpoint) error() error {
+	if j.File == "" && j.Line == 0 && j.Func == "" && j.Code == "" && j.Severity == "" && len(j.Fields) == 0 && j.Prev == nil && len(j.Prevs) == 0 {
+		return errors.New(j.Message)
+	}
+	return j.checkpoint()
+}
@@ -0,0 +1,132 @@
+package checkpoint
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// WrapMany works like Wrap but accepts several previous errors, e.g. if an operation failed
+// for several independent reasons.
+// The resulting Checkpoint unwraps to an error implementing Unwrap() []error,
+// so errors.Is and errors.As inspect all of them.
+// In Error() each previous error is rendered as indented subtree.
+//
+// nil errors in prevs are ignored. If only one previous error remains, WrapMany behaves like Wrap.
+// If none remains, it behaves like From(err), so it returns nil if err is nil.
+// If an Only predicate registered by SetDefaultOptions rejects the previous errors,
+// they are returned combined by errors.Join instead of a Checkpoint.
+func WrapMany(err error, prevs ...error) error {
+	var errs []error
+	for _, prev := range prevs {
+		if prev != nil {
+			errs = append(errs, prev)
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return from(err, nil, 1)
+	case 1:
+		return wrap(errs[0], err, nil, 1)
+	}
+
+	o := newOptions(nil)
+	if newErr := o.handle(err); newErr != nil {
+		return newErr
+	}
+
+	prev := multiError{errs: errs}
+	if !o.wraps(prev) {
+		return errors.Join(errs...)
+	}
+	return o.checkpoint(err, prev, 1)
+}
+
+// Merge combines several independent errors, e.g. collected in a fan-out, into one Checkpoint
+// with the caller information pointing to the call of Merge.
+// Like WrapMany, the Checkpoint unwraps to an error implementing Unwrap() []error,
+// so errors.Is and errors.As match any of the errors.
+// In contrast to WrapMany, none of the errors is the primary one: the message of the Checkpoint
+// just states the number of errors and in Error() each error is rendered as indented subtree
+// labeled by its index.
+//
+// nil errors are ignored. If only one error remains, it is returned directly.
+// It returns nil if no error remains.
+// The Handlers and Only predicates registered by SetDefaultOptions are called with
+// the errors combined by errors.Join, which is also returned if a predicate rejects them.
+func Merge(errs ...error) error {
+	var merged []error
+	for _, err := range errs {
+		if err != nil {
+			merged = append(merged, err)
+		}
+	}
+
+	switch len(merged) {
+	case 0:
+		return nil
+	case 1:
+		return merged[0]
+	}
+
+	o := newOptions(nil)
+	joined := errors.Join(merged...)
+	if newErr := o.handle(joined); newErr != nil {
+		return newErr
+	}
+	if !o.wraps(joined) {
+		return joined
+	}
+
+	return o.checkpoint(mergedErrors(len(merged)), multiError{errs: merged, labeled: true}, 1)
+}
+
+// mergedErrors describes a Checkpoint created by Merge by the number of merged errors.
+// As it is comparable, the descriptions of two Merges of the same number of errors match by errors.Is.
+type mergedErrors int
+
+func (n mergedErrors) Error() string {
+	return strconv.Itoa(int(n)) + " errors"
+}
+
+// Prevs returns the previous errors of a Checkpoint created by WrapMany or Merge with several previous errors,
+// e.g. to render each of them as a branch. It returns nil for all other Checkpoints; use Unwrap for them.
+func (e *Checkpoint) Prevs() []error {
+	if m, ok := e.prev.(multiError); ok {
+		return m.errs
+	}
+	return nil
+}
+
+// multiError bundles several previous errors of a Checkpoint.
+type multiError struct {
+	errs []error
+	// labeled prefixes each rendered error by its index.
+	labeled bool
+}
+
+func (m multiError) Error() string {
+	messages := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (m multiError) Unwrap() []error {
+	return m.errs
+}
+
+// render renders each error as indented subtree.
+func (m multiError) render() string {
+	branches := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		branch := err.Error()
+		if m.labeled {
+			branch = "[" + strconv.Itoa(i) + "] " + branch
+		}
+		branches[i] = indent(branch)
+	}
+	return strings.Join(branches, "\n")
+}
@@ -0,0 +1,327 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Option configures how From and Wrap create a Checkpoint.
+//
+// Migration note: earlier versions defined Option as func(err error) error.
+// Such functions no longer compile as Option and have to be converted to a Handler,
+// which behaves exactly like the old function based options:
+//
+//	// Before:
+//	checkpoint.From(err, func(err error) error { ... })
+//	// After:
+//	checkpoint.From(err, checkpoint.Handler(func(err error) error { ... }))
+//
+// Options returned by the functions of this package, such as IgnoreEOF, need no change.
+type Option interface {
+	apply(o *options)
+}
+
+// options holds the configuration collected from all Options passed to From or Wrap.
+type options struct {
+	handlers   []Handler
+	only       []func(err error) bool
+	path       pathMode
+	skip       int
+	stack      bool
+	keepFrame  func(frame runtime.Frame) bool
+	time       bool
+	goroutine  bool
+	source     bool
+	id         bool
+	message    string
+	redact     []*regexp.Regexp
+	truncate   int
+	sample     int
+	burst      int
+	noCaller   bool
+	fields     map[string]interface{}
+	code       string
+	status     int
+	severity   Severity
+	category   Category
+	retry      bool
+	retryAfter time.Duration
+	ctx        context.Context
+	post       []PostOption
+}
+
+var (
+	defaultOptionsMu sync.RWMutex
+	defaultOptions   []Option
+)
+
+// SetDefaultOptions registers options which are applied to every Checkpoint created by From, Wrap
+// and the related functions.
+// They are applied before the options passed to the individual call, so these can override the defaults.
+// Each call replaces the previously registered defaults; calling it without options removes them.
+//
+// It is safe to call SetDefaultOptions concurrently with creating Checkpoints,
+// but it is intended to be called once during initialization.
+func SetDefaultOptions(opts ...Option) {
+	defaultOptionsMu.Lock()
+	defer defaultOptionsMu.Unlock()
+	defaultOptions = append([]Option(nil), opts...)
+}
+
+// newOptions collects the default options followed by opts.
+func newOptions(opts []Option) options {
+	defaultOptionsMu.RLock()
+	defaults := defaultOptions
+	defaultOptionsMu.RUnlock()
+
+	o := options{path: defaultPath}
+	o.add(defaults)
+	o.add(opts)
+	return o
+}
+
+// collectOptions collects only opts without the default options.
+func collectOptions(opts []Option) options {
+	var o options
+	o.add(opts)
+	return o
+}
+
+// add applies all opts to o.
+func (o *options) add(opts []Option) {
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+}
+
+// handle runs all handlers in order and returns the first non nil error.
+func (o options) handle(err error) error {
+	for _, h := range o.handlers {
+		if newErr := h(err); newErr != nil {
+			return newErr
+		}
+	}
+	return nil
+}
+
+// wraps reports whether err passes all predicates registered by Only.
+func (o options) wraps(err error) bool {
+	for _, predicate := range o.only {
+		if !predicate(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// sampleCounter counts all Checkpoints created with Sample to decide which of them capture caller information.
+var sampleCounter atomic.Uint64
+
+// capture reports whether caller information should be captured for the Checkpoint.
+func (o options) capture() bool {
+	if o.noCaller {
+		return false
+	}
+	if o.sample <= 1 {
+		return true
+	}
+	return sampleCounter.Add(1)%uint64(o.sample) == 0
+}
+
+// optionFunc adapts a simple function to an Option.
+type optionFunc func(o *options)
+
+func (f optionFunc) apply(o *options) {
+	f(o)
+}
+
+// Handler defines a special error handling function which can be used as Option.
+// If it returns nil the normal error handling is done.
+// Else the returned error is returned instead of the Checkpoint.
+//
+// This can be used to define special error handling for special errors
+// such as io.EOF.
+type Handler func(err error) error
+
+func (h Handler) apply(o *options) {
+	o.handlers = append(o.handlers, h)
+}
+
+// PostOption is an Option which is run after the Checkpoint has been constructed by From, Wrap and the related functions.
+// In contrast to a Handler it can inspect the resulting Checkpoint and modify its metadata
+// using Checkpoint.Apply. This allows reusable policies such as:
+//
+//	var timeoutPolicy = checkpoint.PostOption(func(c *checkpoint.Checkpoint) {
+//		if errors.Is(c, context.DeadlineExceeded) {
+//			c.Apply(checkpoint.WithSeverity(checkpoint.SeverityWarning))
+//		}
+//	})
+//
+// PostOptions are run in the order they were passed.
+// They are not run if a Handler returned an error or no Checkpoint was created.
+type PostOption func(c *Checkpoint)
+
+func (p PostOption) apply(o *options) {
+	o.post = append(o.post, p)
+}
+
+// Ignore returns the error directly instead of wrapping it if it is one of the sentinels
+// or wraps one of them according to errors.Is.
+// This allows passing specific errors such as sql.ErrNoRows through unchanged at certain call sites.
+func Ignore(sentinels ...error) Option {
+	return Handler(func(err error) error {
+		for _, sentinel := range sentinels {
+			if errors.Is(err, sentinel) {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Only creates a Checkpoint only for errors for which predicate returns true.
+// All other errors are returned unchanged: From returns err and Wrap returns prev, without adding a Checkpoint.
+// This is the inverse of Ignore and allows e.g. a middleware to decorate only domain errors:
+//
+//	checkpoint.From(err, checkpoint.Only(func(err error) bool {
+//		var domainErr *DomainError
+//		return errors.As(err, &domainErr)
+//	}))
+//
+// The predicate is called after all Handlers such as Ignore or IgnoreEOF, so an error returned by a Handler
+// takes precedence. It is not called for nil errors.
+// If Only is used multiple times, all predicates have to return true.
+func Only(predicate func(err error) bool) Option {
+	return optionFunc(func(o *options) {
+		o.only = append(o.only, predicate)
+	})
+}
+
+// IgnoreEOF returns the io.EOF and io.ErrUnexpectedEOF directly instead of wrapping it.
+// This may be needed to be compatible to several io functions from the standard lib and from other libs.
+// These often check for io.EOF by equality and not by errors.Is because of historical reasons.
+// See https://github.com/golang/go/issues/39155
+//
+// In contrast to Ignore(io.EOF, io.ErrUnexpectedEOF), only the errors themselves are returned directly.
+// Errors wrapping them are no io.EOF for these functions anyway, so they still get a Checkpoint.
+func IgnoreEOF() Option {
+	return Handler(func(err error) error {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// FullPath records the complete path of the source file as reported by the runtime
+// instead of the path relative to the working directory.
+// File() and Error() then return the full path for this Checkpoint.
+func FullPath() Option {
+	return optionFunc(func(o *options) {
+		o.path = pathFull
+	})
+}
+
+// ShortPath records only the directory and the name of the source file, e.g. "handlers/user.go".
+// This is a compromise between the path relative to the working directory, which may be long or
+// contain "../" elements, and the full path.
+// If it is combined with FullPath or BasePath, the option passed last wins.
+func ShortPath() Option {
+	return optionFunc(func(o *options) {
+		o.path = pathShort
+	})
+}
+
+// BasePath records only the name of the source file, e.g. "user.go".
+// If it is combined with FullPath or ShortPath, the option passed last wins.
+func BasePath() Option {
+	return optionFunc(func(o *options) {
+		o.path = pathBase
+	})
+}
+
+// Skip adds n additional stack frames to the caller lookup.
+// This allows helper functions which create a Checkpoint to attribute it to their own caller:
+//
+//	func fail(err error) error {
+//		return checkpoint.From(err, checkpoint.Skip(1))
+//	}
+//
+// Skip is evaluated independently of any Handler, so the caller lookup is the same
+// regardless of the order in which Skip and the error-transforming Options are passed.
+// The default of 0 records the caller of From or Wrap.
+// Multiple Skip options add up.
+func Skip(n int) Option {
+	return optionFunc(func(o *options) {
+		o.skip += n
+	})
+}
+
+// WithTime records the time at which the Checkpoint is created.
+// It can be retrieved by Checkpoint.Time and is also included in the output of Error() using TimeLayout.
+func WithTime() Option {
+	return optionFunc(func(o *options) {
+		o.time = true
+	})
+}
+
+// Redact replaces all matches of the patterns in the message of this Checkpoint by "***".
+// This can be used to prevent sensitive data such as tokens from leaking into logs.
+// It only applies to the message of this Checkpoint and not to any previous error.
+// The describing error itself stays untouched, so errors.Is and errors.As work as usual.
+func Redact(patterns ...*regexp.Regexp) Option {
+	return optionFunc(func(o *options) {
+		o.redact = append(o.redact, patterns...)
+	})
+}
+
+// ReplaceMessage renders message instead of the message of the error describing this Checkpoint.
+// This can be used to present a cleaner message than the one of an upstream error.
+// In contrast to Wrap it does not add a layer and the describing error itself stays untouched,
+// so errors.Is and errors.As work as usual.
+// Redact and Truncate are applied to the replacement as well.
+// An empty message does not replace anything.
+func ReplaceMessage(message string) Option {
+	return optionFunc(func(o *options) {
+		o.message = message
+	})
+}
+
+// Sample captures caller information only for 1 in rate Checkpoints created with this option.
+// All other *Checkpoints are created without caller information (and without a stack if WithStack is used),
+// which avoids the cost of the runtime lookup in hot paths producing many errors.
+// A rate of 1 or less captures the caller information for every Checkpoint.
+// The counter deciding which Checkpoints are sampled is shared by all uses of Sample.
+func Sample(rate int) Option {
+	return optionFunc(func(o *options) {
+		o.sample = rate
+	})
+}
+
+// NoCaller creates the Checkpoint without looking up any caller information (and without a stack if WithStack is used).
+// This avoids the cost of the runtime lookup entirely for Checkpoints which are only needed for the error chain
+// and their message, e.g. in latency sensitive loops.
+// Such a Checkpoint is rendered as "<caller unavailable>", just like a Checkpoint skipped by Sample.
+func NoCaller() Option {
+	return optionFunc(func(o *options) {
+		o.noCaller = true
+	})
+}
+
+// Truncate limits the message of this Checkpoint to max runes when it is rendered.
+// If the message is longer, it is cut and an ellipsis ("…") is appended.
+// The describing error itself stays untouched, so errors.Is and errors.As work as usual.
+// A max of 0 or less disables the truncation.
+func Truncate(max int) Option {
+	return optionFunc(func(o *options) {
+		o.truncate = max
+	})
+}
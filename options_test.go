@@ -0,0 +1,119 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactCreditCard(t *testing.T) {
+	card := regexp.MustCompile(`\b(?:\d{4}[ -]?){3}\d{4}\b`)
+	prev := errors.New("charge 4111-1111-1111-1111 declined")
+
+	err := Wrap(From(prev), errors.New("payment for 4111 1111 1111 1111 failed"), Redact(card))
+
+	got := err.Error()
+	if strings.Contains(got, "4111 1111 1111 1111") {
+		t.Errorf("Error() contains the unmasked card number:\n%s", got)
+	}
+	if !strings.Contains(got, "payment for *** failed") {
+		t.Errorf("Error() does not contain the masked message:\n%s", got)
+	}
+	// Redact only applies to the own message and not to previous layers.
+	if !strings.Contains(got, "charge 4111-1111-1111-1111 declined") {
+		t.Errorf("Error() redacted the message of a previous layer:\n%s", got)
+	}
+	if !errors.Is(err, prev) {
+		t.Errorf("errors.Is(err, prev) = false, want true")
+	}
+}
+
+var benchErr error
+
+func BenchmarkFrom(b *testing.B) {
+	err := errors.New("failed")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchErr = From(err)
+	}
+}
+
+func BenchmarkFromNoCaller(b *testing.B) {
+	err := errors.New("failed")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchErr = From(err, NoCaller())
+	}
+}
+
+func TestReplaceMessageKeepsOriginal(t *testing.T) {
+	original := errors.New("pq: duplicate key value violates unique constraint")
+
+	err := From(original, ReplaceMessage("user already exists"))
+	if !errors.Is(err, original) {
+		t.Errorf("errors.Is(err, original) = false, want true")
+	}
+
+	got := err.Error()
+	if !strings.Contains(got, "user already exists") {
+		t.Errorf("Error() does not contain the replaced message:\n%s", got)
+	}
+	if strings.Contains(got, original.Error()) {
+		t.Errorf("Error() still contains the original message:\n%s", got)
+	}
+}
+
+func TestIgnoreEOF(t *testing.T) {
+	if err := From(io.EOF, IgnoreEOF()); err != io.EOF {
+		t.Errorf("From(io.EOF, IgnoreEOF()) = %v, want io.EOF", err)
+	}
+	if err := From(io.ErrUnexpectedEOF, IgnoreEOF()); err != io.ErrUnexpectedEOF {
+		t.Errorf("From(io.ErrUnexpectedEOF, IgnoreEOF()) = %v, want io.ErrUnexpectedEOF", err)
+	}
+
+	wrapped := fmt.Errorf("read header: %w", io.EOF)
+	if err := From(wrapped, IgnoreEOF()); !isCheckpoint(err) {
+		t.Errorf("From(wrapped EOF, IgnoreEOF()) = %T, want *Checkpoint", err)
+	}
+}
+
+func TestSetDefaultOptionsConcurrently(t *testing.T) {
+	defer SetDefaultOptions()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetDefaultOptions(FullPath())
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_ = From(errors.New("failed"))
+	}
+	<-done
+}
+
+func TestDefaultOnlyAppliesToAllConstructors(t *testing.T) {
+	defer SetDefaultOptions()
+	SetDefaultOptions(Only(func(err error) bool { return false }))
+
+	prev := errors.New("prev")
+	for name, err := range map[string]error{
+		"New":      New("failed"),
+		"Newf":     Newf("failed %d", 1),
+		"Fromf":    Fromf(prev, "failed"),
+		"Wrapf":    Wrapf(prev, "failed"),
+		"WrapMany": WrapMany(errors.New("failed"), prev, errors.New("other")),
+		"Merge":    Merge(prev, errors.New("other")),
+	} {
+		if isCheckpoint(err) {
+			t.Errorf("%s created a Checkpoint although Only rejects all errors", name)
+		}
+		if name != "New" && name != "Newf" && !errors.Is(err, prev) {
+			t.Errorf("%s returned %v, want the previous errors", name, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Recover converts a value returned by recover() into a Checkpoint.
+// It is intended to be used in a deferred function:
+//
+//	defer func() {
+//		if err := checkpoint.Recover(recover()); err != nil {
+//			log.Println(err)
+//		}
+//	}()
+//
+// Errors are used directly, so errors.Is and errors.As still work with them.
+// Any other value is converted to an error with the message "panic: <value>".
+// The full stack is recorded like with WithStack, and the caller information points to the location
+// of the panic instead of the deferred function, if it can be determined.
+// It returns nil if recovered is nil, which means that there was no panic.
+func Recover(recovered interface{}) error {
+	if recovered == nil {
+		return nil
+	}
+
+	err, ok := recovered.(error)
+	if !ok {
+		if s, isString := recovered.(string); isString {
+			err = errors.New("panic: " + s)
+		} else {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}
+
+	o := newOptions([]Option{WithStack()})
+	c := o.checkpoint(err, nil, 1)
+	c.attributeToPanic(o.path)
+	return c
+}
+
+// GuardFunc runs fn and returns its error.
+// If fn panics, the panic is recovered and returned as Checkpoint created by Recover.
+func GuardFunc(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = Recover(r)
+		}
+	}()
+
+	return fn()
+}
+
+// attributeToPanic searches the recorded stack for the location of a panic and,
+// if found, replaces the caller information and trims the stack to start there.
+// The file of the panic is recorded according to mode like the file of any other Checkpoint.
+func (e *Checkpoint) attributeToPanic(mode pathMode) {
+	panicking := false
+	for i, pc := range e.stack {
+		frame, _ := runtime.CallersFrames(e.stack[i : i+1]).Next()
+		if frame.Function == "runtime.gopanic" {
+			panicking = true
+			continue
+		}
+		// Skip the runtime frames between the panic and the panicking function
+		// (e.g. runtime.sigpanic for a nil pointer dereference).
+		if !panicking || strings.HasPrefix(frame.Function, "runtime.") {
+			continue
+		}
+
+		e.pc = pc
+		e.file = mode.format(frame.File)
+		e.line = frame.Line
+		e.fn = shortFunc(frame.Function)
+		e.pkg = packagePath(frame.Function)
+		e.callerOk = true
+		e.stack = append([]uintptr(nil), e.stack[i:]...)
+		return
+	}
+}
@@ -0,0 +1,39 @@
+package checkpoint
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGuardFuncPathMode(t *testing.T) {
+	defer SetDefaultOptions()
+	SetDefaultOptions(FullPath())
+
+	err := GuardFunc(func() error {
+		panic("boom")
+	})
+
+	c, ok := asCheckpoint(err)
+	if !ok {
+		t.Fatalf("GuardFunc() = %T, want *Checkpoint", err)
+	}
+	if got := c.File(); !filepath.IsAbs(got) || filepath.Base(got) != "panic_test.go" {
+		t.Errorf("File() = %q, want the full path of panic_test.go", got)
+	}
+}
+
+func TestGuardFuncPackage(t *testing.T) {
+	err := GuardFunc(func() error {
+		strings.Repeat("x", -1)
+		return nil
+	})
+
+	c, _ := asCheckpoint(err)
+	if got, want := c.Func(), "strings.Repeat"; got != want {
+		t.Errorf("Func() = %q, want %q", got, want)
+	}
+	if got, want := c.Package(), "strings"; got != want {
+		t.Errorf("Package() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/aligator/checkpoint/pkgerrors
+
+go 1.23
+
+require (
+	github.com/aligator/checkpoint v0.2.0
+	github.com/pkg/errors v0.9.1
+)
@@ -0,0 +1,75 @@
+// Package pkgerrors makes checkpoint chains compatible with tools which detect stack traces
+// using the StackTracer interface of github.com/pkg/errors, such as the Sentry SDK.
+package pkgerrors
+
+import (
+	"github.com/aligator/checkpoint"
+	"github.com/pkg/errors"
+)
+
+// StackTracer is the interface used by github.com/pkg/errors (and tools supporting it) to expose a stack trace.
+type StackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// StackTrace returns the stack trace of the chain of err in the format of github.com/pkg/errors.
+// It uses the stack of the innermost Checkpoint created with checkpoint.WithStack as it is the closest
+// to the origin of the error.
+// If no Checkpoint has a stack, a single frame is synthesized from the caller information of the
+// innermost Checkpoint.
+// It returns nil if the chain contains no Checkpoint with caller information.
+func StackTrace(err error) errors.StackTrace {
+	var stack []uintptr
+	var pc uintptr
+	checkpoint.Walk(err, func(c *checkpoint.Checkpoint) bool {
+		if s := c.Stack(); len(s) > 0 {
+			stack = s
+		}
+		if c.PC() != 0 {
+			pc = c.PC()
+		}
+		return true
+	})
+
+	if len(stack) == 0 {
+		if pc == 0 {
+			return nil
+		}
+		stack = []uintptr{pc}
+	}
+
+	trace := make(errors.StackTrace, len(stack))
+	for i, pc := range stack {
+		trace[i] = errors.Frame(pc)
+	}
+	return trace
+}
+
+// Error wraps err so that it implements StackTracer.
+// The wrapped error can still be inspected by errors.Is and errors.As.
+// A Checkpoint itself has a StackTrace method returning its program counters, which is enough for tools
+// detecting stacks by reflection. The wrapper is only needed for tools checking for exactly StackTracer.
+// It returns nil for a nil error.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return stackError{err: err}
+}
+
+type stackError struct {
+	err error
+}
+
+func (e stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e stackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace implements StackTracer.
+func (e stackError) StackTrace() errors.StackTrace {
+	return StackTrace(e.err)
+}
@@ -0,0 +1,42 @@
+package checkpoint
+
+import "sync"
+
+var (
+	checkpointPool = sync.Pool{
+		New: func() interface{} {
+			return new(Checkpoint)
+		},
+	}
+
+	stackPool = sync.Pool{
+		New: func() interface{} {
+			s := make([]uintptr, maxStackDepth)
+			return &s
+		},
+	}
+)
+
+// Release returns all Checkpoints of the chain of err to an internal pool,
+// so that they can be reused by later calls to From, Wrap and the related functions.
+// This reduces the allocations in hot paths which produce many errors.
+//
+// Pooling errors is unusual and dangerous: Release must only be called once the error is
+// fully handled and neither err nor any error of its chain is referenced anywhere anymore.
+// This also means that a Checkpoint which is part of several chains (e.g. wrapped twice)
+// must not be released twice.
+// To make use after release easier to detect, all fields of the released Checkpoints are zeroed,
+// so they render as empty Checkpoints without caller information.
+//
+// Calling Release is optional. Checkpoints which are never released are simply garbage collected.
+func Release(err error) {
+	for _, c := range Flatten(err) {
+		// Only stacks allocated by the pool are put back.
+		if cap(c.stack) == maxStackDepth {
+			stack := c.stack[:cap(c.stack)]
+			stackPool.Put(&stack)
+		}
+		*c = Checkpoint{}
+		checkpointPool.Put(c)
+	}
+}
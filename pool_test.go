@@ -0,0 +1,23 @@
+package checkpoint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReleaseAnnotatedKeepsOriginalStack(t *testing.T) {
+	orig, _ := asCheckpoint(From(errors.New("x"), WithStack()))
+	want := orig.StackString()
+
+	Release(Annotate(orig, WithCode("c")))
+	other := otherStack()
+
+	if got := orig.StackString(); got != want {
+		t.Errorf("StackString() of the original changed after releasing the annotated copy:\n%s", got)
+	}
+	Release(other)
+}
+
+func otherStack() error {
+	return From(errors.New("y"), WithStack())
+}
@@ -0,0 +1,54 @@
+package checkpoint
+
+import "io"
+
+// Fprint writes err to w.
+// Checkpoints are rendered with their whole chain as by Error() using WriteTo,
+// all other errors are written using their Error() method.
+// Nothing is written if err is nil.
+// It returns the error of the underlying writer, if any.
+func Fprint(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+	if c, ok := asCheckpoint(err); ok {
+		_, writeErr := c.WriteTo(w)
+		return writeErr
+	}
+	_, writeErr := io.WriteString(w, err.Error())
+	return writeErr
+}
+
+// Fprintln writes err to w like Fprint and terminates the output with a line break
+// if it does not already end with one.
+// Nothing is written if err is nil.
+// It returns the error of the underlying writer, if any.
+func Fprintln(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	lw := &lastByteWriter{w: w}
+	if writeErr := Fprint(lw, err); writeErr != nil {
+		return writeErr
+	}
+	if lw.last == '\n' {
+		return nil
+	}
+	_, writeErr := io.WriteString(w, "\n")
+	return writeErr
+}
+
+// lastByteWriter remembers the last byte written to w.
+type lastByteWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (l *lastByteWriter) Write(p []byte) (int, error) {
+	n, err := l.w.Write(p)
+	if n > 0 {
+		l.last = p[n-1]
+	}
+	return n, err
+}
@@ -0,0 +1,339 @@
+package checkpoint
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// The following variables configure the format used by Error().
+// The defaults render a Checkpoint as
+//
+//	File: foo.go:10 (pkg.function)
+//		message
+var (
+	// FilePrefix is written in front of the file of each Checkpoint.
+	FilePrefix = "File: "
+	// LineSeparator separates the file and the line of each Checkpoint.
+	LineSeparator = ":"
+	// Indent is written in front of each message line.
+	Indent = "\t"
+	// TimeLayout is the layout used to render the time recorded by WithTime.
+	// It only affects the rendering and not the value returned by Checkpoint.Time.
+	TimeLayout = time.RFC3339
+)
+
+// ModuleRoot is trimmed from the beginning of the file paths of all Checkpoints when they are rendered.
+// This is mainly useful in combination with FullPath to turn absolute build paths
+// like "/home/ci/src/github.com/us/app/foo.go" into "app/foo.go" by setting it to "/home/ci/src/github.com/us".
+// Paths not starting with ModuleRoot are left unchanged.
+// As it is applied at render time, it also affects already created Checkpoints.
+var ModuleRoot = ""
+
+// trimModuleRoot removes ModuleRoot from the beginning of file.
+func trimModuleRoot(file string) string {
+	if ModuleRoot == "" || !strings.HasPrefix(file, ModuleRoot) {
+		return file
+	}
+
+	rest := file[len(ModuleRoot):]
+	if strings.HasSuffix(ModuleRoot, "/") || strings.HasSuffix(ModuleRoot, `\`) {
+		return rest
+	}
+	// Only trim complete path elements.
+	if rest == "" || (rest[0] != '/' && rest[0] != '\\') {
+		return file
+	}
+	return rest[1:]
+}
+
+// callerUnavailable is rendered instead of the file if a Checkpoint has no caller information.
+const callerUnavailable = "<caller unavailable>"
+
+// OneLineSeparator separates the layers of the chain rendered by OneLine.
+var OneLineSeparator = " <- "
+
+var (
+	formatterMu sync.RWMutex
+	formatter   func(c *Checkpoint) string
+)
+
+// SetFormatter replaces the formatting of a single Checkpoint used by Error().
+// The formatter only renders the given Checkpoint itself, e.g. using File, Line, Func and Message.
+// Rendering the previous errors is still done by Error(), so the formatter must not call Error()
+// of the given Checkpoint.
+// Passing nil restores the default format.
+//
+// It is safe to call SetFormatter concurrently with rendering Checkpoints,
+// but it is intended to be called once during initialization.
+func SetFormatter(fn func(c *Checkpoint) string) {
+	formatterMu.Lock()
+	defer formatterMu.Unlock()
+	formatter = fn
+}
+
+// formatNode renders only the given Checkpoint without its previous errors.
+func formatNode(c *Checkpoint) string {
+	formatterMu.RLock()
+	fn := formatter
+	formatterMu.RUnlock()
+
+	if fn != nil {
+		return fn(c)
+	}
+	return defaultFormatNode(c)
+}
+
+// defaultFormatNode renders only the given Checkpoint in the default format.
+func defaultFormatNode(c *Checkpoint) string {
+	return c.header() + c.renderedSource() + "\n" + Indent + c.renderedMessage()
+}
+
+var (
+	renderFilterMu sync.RWMutex
+	renderFilter   func(c *Checkpoint) bool
+)
+
+// FilterRender registers a filter deciding which Checkpoints are rendered by Error(), WriteTo,
+// ReverseString and ColorString. Checkpoints for which keep returns false are left out of the output,
+// e.g. to hide Checkpoints created in generated code.
+// The chain itself stays unchanged, so filtered Checkpoints are still returned by Frames and Flatten
+// and still participate in errors.Is and errors.As.
+// Passing nil renders all Checkpoints again.
+//
+// It is safe to call FilterRender concurrently with rendering Checkpoints,
+// but it is intended to be called once during initialization.
+func FilterRender(keep func(c *Checkpoint) bool) {
+	renderFilterMu.Lock()
+	defer renderFilterMu.Unlock()
+	renderFilter = keep
+}
+
+// rendered reports whether c passes the filter registered by FilterRender.
+func rendered(c *Checkpoint) bool {
+	renderFilterMu.RLock()
+	keep := renderFilter
+	renderFilterMu.RUnlock()
+
+	return keep == nil || keep(c)
+}
+
+// redactedText is used to replace matches of Redact patterns.
+const redactedText = "***"
+
+// redact replaces all matches of the Redact patterns of the Checkpoint in message.
+func (c *Checkpoint) redact(message string) string {
+	for _, pattern := range c.redactions {
+		message = pattern.ReplaceAllLiteralString(message, redactedText)
+	}
+	return message
+}
+
+// ellipsis is appended to messages shortened by Truncate.
+const ellipsis = "…"
+
+// truncate shortens message to the Truncate limit of the Checkpoint.
+// It counts runes, not bytes, so the result is always valid UTF-8.
+func (c *Checkpoint) truncate(message string) string {
+	if c.truncateAt <= 0 || utf8.RuneCountInString(message) <= c.truncateAt {
+		return message
+	}
+
+	runes := 0
+	for i := range message {
+		if runes == c.truncateAt {
+			return message[:i] + ellipsis
+		}
+		runes++
+	}
+	return message
+}
+
+// The following variables configure how errors in the chain which are no Checkpoints are rendered by Error().
+// As such errors have no location, no file line is rendered for them, only their message.
+var (
+	// ForeignPrefix is written in front of the message of each error which is no Checkpoint,
+	// e.g. "caused by: ". Further lines of multi-line messages are aligned below the first one.
+	ForeignPrefix = ""
+	// IndentForeign controls whether the messages of errors which are no Checkpoints are indented by Indent
+	// like the messages of Checkpoints.
+	IndentForeign = true
+)
+
+// formatForeign renders an error which is no Checkpoint.
+// As it has no location, only its message is rendered, followed by its own stack if ForeignStacks is enabled.
+func formatForeign(err error) string {
+	return foreignMessage(err.Error()) + foreignStack(err)
+}
+
+// foreignMessage renders the message of an error which is no Checkpoint according to
+// ForeignPrefix and IndentForeign.
+func foreignMessage(message string) string {
+	if ForeignPrefix != "" {
+		align := strings.Repeat(" ", utf8.RuneCountInString(ForeignPrefix))
+		message = ForeignPrefix + strings.ReplaceAll(message, "\n", "\n"+align)
+	}
+	if IndentForeign {
+		message = indent(message)
+	}
+	return message
+}
+
+// indent prefixes each line of s by Indent.
+func indent(s string) string {
+	return Indent + strings.ReplaceAll(s, "\n", "\n"+Indent)
+}
+
+// layers renders each layer of the chain on its own, ordered from the outermost to the innermost.
+// Checkpoints are rendered using node and other errors using foreign.
+// The branches of errors created by WrapMany or Merge are rendered as one layer of indented subtrees like in Error().
+func (e *Checkpoint) layers(node func(c *Checkpoint) string, foreign func(err error) string) []string {
+	var res []string
+	var seen visited
+	var err error = e
+	for err != nil {
+		if depthExceeded(len(res)) {
+			res = append(res, truncatedLayers(err))
+			break
+		}
+
+		if m, ok := err.(multiError); ok {
+			res = append(res, m.render())
+			break
+		}
+		c, ok := asCheckpoint(err)
+		if !ok {
+			res = append(res, foreign(err))
+			break
+		}
+		if !seen.first(c) {
+			res = append(res, cycleMarker)
+			break
+		}
+		repeats, prev := c.collapse()
+		if rendered(c) {
+			res = append(res, withRepeats(node(c), repeats))
+		}
+		err = prev
+	}
+	return res
+}
+
+// MaxDepth limits the number of layers rendered by Error(), WriteTo, ReverseString and ColorString.
+// The omitted layers are replaced by a marker such as "… (truncated, 3 more)".
+// Only the rendering is affected, the chain itself stays unchanged.
+// The default of 0 renders all layers.
+var MaxDepth = 0
+
+// depthExceeded reports whether no further layer may be rendered after depth layers.
+func depthExceeded(depth int) bool {
+	return MaxDepth > 0 && depth >= MaxDepth
+}
+
+// truncatedLayers renders the marker replacing all layers starting at err.
+func truncatedLayers(err error) string {
+	omitted := 0
+	for i := 0; err != nil && i < maxChainLength; i++ {
+		c, ok := asCheckpoint(err)
+		if !ok {
+			omitted++
+			break
+		}
+		if rendered(c) {
+			omitted++
+		}
+		err = c.prev
+	}
+	return ellipsis + " (truncated, " + strconv.Itoa(omitted) + " more)"
+}
+
+// CollapseRepeats enables collapsing runs of adjacent Checkpoints created at the same file and line
+// (e.g. by recursive functions) into a single one when rendering the chain.
+// The collapsed Checkpoint is marked by the number of repetitions, e.g. "File: foo.go:10 (x12)".
+// It also collapses identical adjacent frames in Checkpoint.StackString.
+// Only the rendering is affected, the chain itself stays unchanged.
+var CollapseRepeats = false
+
+// collapse returns how often the Checkpoint is repeated by its adjacent previous Checkpoints
+// if CollapseRepeats is enabled, and the previous error following the repetitions.
+func (c *Checkpoint) collapse() (repeats int, prev error) {
+	repeats, prev = 1, c.prev
+	if !CollapseRepeats || !c.callerOk {
+		return repeats, prev
+	}
+
+	for i := 0; i < maxChainLength; i++ {
+		p, ok := asCheckpoint(prev)
+		if !ok || !p.callerOk || p.file != c.file || p.line != c.line {
+			break
+		}
+		repeats++
+		prev = p.prev
+	}
+	return repeats, prev
+}
+
+// withRepeats marks the first line of a rendered Checkpoint with the number of repetitions.
+func withRepeats(node string, repeats int) string {
+	if repeats <= 1 {
+		return node
+	}
+
+	marker := " (x" + strconv.Itoa(repeats) + ")"
+	if i := strings.Index(node, "\n"); i >= 0 {
+		return node[:i] + marker + node[i:]
+	}
+	return node + marker
+}
+
+// ReverseString renders the checkpoint chain in the same format as Error() but in reversed order.
+// The innermost layer (the original cause) comes first and the outermost layer last.
+func (e *Checkpoint) ReverseString() string {
+	layers := e.layers(formatNode, formatForeign)
+	for i, j := 0, len(layers)-1; i < j; i, j = i+1, j-1 {
+		layers[i], layers[j] = layers[j], layers[i]
+	}
+	return strings.Join(layers, "\n")
+}
+
+// OneLine renders the whole checkpoint chain on a single line, e.g.
+//
+//	foo.go:10: wrap msg <- bar.go:3: root msg
+//
+// The layers are separated by OneLineSeparator.
+// Line breaks inside of the messages are replaced by spaces.
+// The layers of the branches of errors created by WrapMany or Merge follow each other like in Frames,
+// prefixed by their branch, e.g. "[1] bar.go:5: other msg".
+func (e *Checkpoint) OneLine() string {
+	var b strings.Builder
+	for i, frame := range e.Frames() {
+		if i > 0 {
+			b.WriteString(OneLineSeparator)
+		}
+		b.WriteString(frame.oneLine())
+	}
+	return b.String()
+}
+
+// lineBreaks replaces all line breaks by spaces.
+var lineBreaks = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the same single line as OneLine.
+// In contrast to OneLine it also replaces line breaks contained in OneLineSeparator,
+// so the result never contains any line break.
+func (e *Checkpoint) MarshalText() ([]byte, error) {
+	return []byte(lineBreaks.Replace(e.OneLine())), nil
+}
+
+// oneLine renders the Frame as "file:line: message" on a single line.
+// Frames without file only render the message. Frames of a branch are prefixed by the branch like by String.
+func (f Frame) oneLine() string {
+	message := lineBreaks.Replace(f.Message)
+	if f.File == "" {
+		return f.branchLabel() + message
+	}
+	return f.branchLabel() + f.File + ":" + strconv.Itoa(f.Line) + ": " + message
+}
@@ -0,0 +1,63 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeLayout(t *testing.T) {
+	defer func(layout string) { TimeLayout = layout }(TimeLayout)
+
+	c, _ := asCheckpoint(From(errors.New("failed"), WithTime()))
+	recorded := c.Time()
+
+	TimeLayout = time.RFC3339
+	if got := c.Error(); !strings.Contains(got, " at "+recorded.Format(time.RFC3339)) {
+		t.Errorf("Error() does not contain the time in the default layout:\n%s", got)
+	}
+
+	TimeLayout = "2006/01/02 15h04"
+	if got := c.Error(); !strings.Contains(got, " at "+recorded.Format("2006/01/02 15h04")) {
+		t.Errorf("Error() does not contain the time in the custom layout:\n%s", got)
+	}
+	if !c.Time().Equal(recorded) {
+		t.Errorf("Time() = %v after changing TimeLayout, want %v", c.Time(), recorded)
+	}
+}
+
+func TestForeignErrorHasNoFileLine(t *testing.T) {
+	foreign := fmt.Errorf("dial tcp: %w", errors.New("connection refused"))
+
+	got := Wrap(foreign, errors.New("connect")).Error()
+	if strings.Contains(got, "unknown") || strings.Contains(got, callerUnavailable) {
+		t.Errorf("Error() renders a fake file line for the foreign error:\n%s", got)
+	}
+	if n := strings.Count(got, FilePrefix); n != 1 {
+		t.Errorf("Error() renders %d file lines, want 1:\n%s", n, got)
+	}
+	if !strings.HasSuffix(got, "\n"+Indent+"dial tcp: connection refused") {
+		t.Errorf("Error() does not end with the indented foreign message:\n%s", got)
+	}
+}
+
+func TestCallerUnavailable(t *testing.T) {
+	got := From(errors.New("failed"), NoCaller()).Error()
+	if want := FilePrefix + callerUnavailable + "\n" + Indent + "failed"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestReverseStringRendersBranches(t *testing.T) {
+	err := Merge(From(errors.New("a")), errors.New("b"))
+	c, _ := asCheckpoint(err)
+
+	got := c.ReverseString()
+	for _, want := range []string{Indent + "[0] " + FilePrefix, Indent + "[1] b"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ReverseString() does not contain %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package checkpoint
+
+import "time"
+
+// WithRetry marks the Checkpoint as retryable after the suggested delay.
+// A delay of 0 marks it as retryable immediately.
+// The hint of a whole chain can be retrieved by RetryAfter.
+func WithRetry(after time.Duration) Option {
+	return optionFunc(func(o *options) {
+		o.retry = true
+		o.retryAfter = after
+	})
+}
+
+// RetryAfter returns the delay set by WithRetry for this Checkpoint.
+// ok is false if WithRetry was not used.
+func (e *Checkpoint) RetryAfter() (after time.Duration, ok bool) {
+	return e.retryAfter, e.retry
+}
+
+// RetryAfter returns the delay set by WithRetry for the outermost Checkpoint of the chain of err
+// which is marked as retryable, as the outermost layer has the most recent context.
+// ok is false if no Checkpoint of the chain is marked as retryable.
+func RetryAfter(err error) (after time.Duration, ok bool) {
+	Walk(err, func(c *Checkpoint) bool {
+		if !c.retry {
+			return true
+		}
+		after, ok = c.retryAfter, true
+		return false
+	})
+	return after, ok
+}
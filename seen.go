@@ -0,0 +1,86 @@
+package checkpoint
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Default limits of the cache used by Seen.
+const (
+	defaultSeenSize = 1024
+	defaultSeenTTL  = time.Hour
+)
+
+// seenEntry is an element of the cache used by Seen.
+type seenEntry struct {
+	fingerprint string
+	first       time.Time
+}
+
+var (
+	seenMu    sync.Mutex
+	seenSize  = defaultSeenSize
+	seenTTL   = defaultSeenTTL
+	seenOrder = list.New() // Most recently used first.
+	seenIndex = make(map[string]*list.Element)
+)
+
+// SetSeenCache configures the cache used by Seen and clears all fingerprints observed so far.
+// At most size fingerprints are remembered; if more are observed, the least recently used ones are forgotten.
+// A fingerprint is forgotten as well once ttl has passed since it was first observed.
+// Non-positive values restore the defaults of 1024 fingerprints and one hour.
+//
+// It is safe to call SetSeenCache concurrently, but it is intended to be called once during initialization.
+func SetSeenCache(size int, ttl time.Duration) {
+	if size <= 0 {
+		size = defaultSeenSize
+	}
+	if ttl <= 0 {
+		ttl = defaultSeenTTL
+	}
+
+	seenMu.Lock()
+	defer seenMu.Unlock()
+	seenSize = size
+	seenTTL = ttl
+	seenOrder.Init()
+	seenIndex = make(map[string]*list.Element)
+}
+
+// Seen reports whether the Fingerprint of err was already observed by Seen within the configured time window.
+// It returns false the first time a fingerprint is observed and true for all further calls until it
+// is forgotten (see SetSeenCache). This allows alerting code to suppress duplicate alerts for recurring errors:
+//
+//	if !checkpoint.Seen(err) {
+//		alert(err)
+//	}
+//
+// It is safe to call Seen concurrently.
+func Seen(err error) bool {
+	fingerprint := Fingerprint(err)
+	now := time.Now()
+
+	seenMu.Lock()
+	defer seenMu.Unlock()
+
+	if element, ok := seenIndex[fingerprint]; ok {
+		entry := element.Value.(*seenEntry)
+		if now.Sub(entry.first) < seenTTL {
+			seenOrder.MoveToFront(element)
+			return true
+		}
+		// The window has passed, so observe it again for the first time.
+		entry.first = now
+		seenOrder.MoveToFront(element)
+		return false
+	}
+
+	seenIndex[fingerprint] = seenOrder.PushFront(&seenEntry{fingerprint: fingerprint, first: now})
+	for seenOrder.Len() > seenSize {
+		oldest := seenOrder.Back()
+		seenOrder.Remove(oldest)
+		delete(seenIndex, oldest.Value.(*seenEntry).fingerprint)
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package checkpoint
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Severity classifies how serious an error is.
+// The zero value means that no severity was set.
+type Severity int
+
+// The supported severities ordered from the least to the most serious.
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityWarning
+	SeverityError
+	SeverityFatal
+)
+
+// String returns the lower case name of the severity, e.g. "warning".
+func (s Severity) String() string {
+	switch s {
+	case 0:
+		return ""
+	case SeverityDebug:
+		return "debug"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	}
+	return "severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+// parseSeverity converts the result of Severity.String back to a Severity.
+// It returns 0 for unknown names.
+func parseSeverity(s string) Severity {
+	switch s {
+	case "debug":
+		return SeverityDebug
+	case "warning":
+		return SeverityWarning
+	case "error":
+		return SeverityError
+	case "fatal":
+		return SeverityFatal
+	}
+
+	if strings.HasPrefix(s, "severity(") && strings.HasSuffix(s, ")") {
+		if n, err := strconv.Atoi(s[len("severity(") : len(s)-1]); err == nil {
+			return Severity(n)
+		}
+	}
+	return 0
+}
+
+// WithSeverity sets the severity of the Checkpoint.
+// The severity of a whole chain can be retrieved by SeverityOf.
+func WithSeverity(s Severity) Option {
+	return optionFunc(func(o *options) {
+		o.severity = s
+	})
+}
+
+// Severity returns the severity set for this Checkpoint by WithSeverity.
+// It is 0 if no severity was set.
+func (e *Checkpoint) Severity() Severity {
+	return e.severity
+}
+
+// SeverityOf returns the highest severity set by WithSeverity in the chain of err.
+// It returns SeverityError if no Checkpoint of the chain has a severity.
+func SeverityOf(err error) Severity {
+	var severity Severity
+	Walk(err, func(c *Checkpoint) bool {
+		if c.severity > severity {
+			severity = c.severity
+		}
+		return true
+	})
+
+	if severity == 0 {
+		return SeverityError
+	}
+	return severity
+}
@@ -0,0 +1,66 @@
+package checkpoint
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer.
+// The Checkpoint is logged as group with the attributes "message", "file", "line", "func", "code", "severity"
+// and the group "fields" containing the fields attached by WithField.
+// The previous error is nested as group "prev" using the same structure.
+// The branches of errors created by WrapMany or Merge are nested as group "prevs" instead,
+// which contains one group per branch keyed by its index.
+// Previous errors which are no Checkpoints only contain the "message".
+//
+// The key of the group is chosen by the caller, e.g.
+//
+//	slog.Error("failed", "err", err)
+func (e *Checkpoint) LogValue() slog.Value {
+	var seen visited
+	return slog.GroupValue(logAttrs(e, &seen)...)
+}
+
+// logAttrs returns the slog attributes of err and recursively all its previous errors.
+func logAttrs(err error, seen *visited) []slog.Attr {
+	c, ok := asCheckpoint(err)
+	if !ok {
+		return []slog.Attr{slog.String("message", err.Error())}
+	}
+	if !seen.first(c) {
+		return []slog.Attr{slog.String("message", cycleMarker)}
+	}
+
+	attrs := make([]slog.Attr, 0, 8)
+	attrs = append(attrs, slog.String("message", c.Message()))
+	if c.callerOk {
+		attrs = append(attrs,
+			slog.String("file", c.File()),
+			slog.Int("line", c.line),
+			slog.String("func", c.fn),
+		)
+	}
+	if c.code != "" {
+		attrs = append(attrs, slog.String("code", c.code))
+	}
+	if c.severity != 0 {
+		attrs = append(attrs, slog.String("severity", c.severity.String()))
+	}
+	if len(c.fields) > 0 {
+		fields := make([]slog.Attr, 0, len(c.fields))
+		for key, value := range c.fields {
+			fields = append(fields, slog.Any(key, value))
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fields...)})
+	}
+	if m, ok := c.prev.(multiError); ok {
+		branches := make([]slog.Attr, len(m.errs))
+		for i, branch := range m.errs {
+			branches[i] = slog.Attr{Key: strconv.Itoa(i), Value: slog.GroupValue(logAttrs(branch, seen)...)}
+		}
+		attrs = append(attrs, slog.Attr{Key: "prevs", Value: slog.GroupValue(branches...)})
+	} else if c.prev != nil {
+		attrs = append(attrs, slog.Attr{Key: "prev", Value: slog.GroupValue(logAttrs(c.prev, seen)...)})
+	}
+	return attrs
+}
@@ -0,0 +1,74 @@
+package checkpoint
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+)
+
+// WithSource records the location of the Checkpoint so that the source code of the line it was created at
+// can be shown. It can be retrieved by Checkpoint.SourceLine and is also rendered by Error() below
+// the caller information, like some panic printers do:
+//
+//	File: foo.go:10 (pkg.function)
+//		> return checkpoint.From(err, checkpoint.WithSource())
+//		message
+//
+// The source file is only read when the line is needed for the first time and the result is cached.
+// If the source file is not available at runtime, e.g. for deployed binaries, nothing is shown.
+// Reading source files is slow, so this is intended for debugging only.
+func WithSource() Option {
+	return optionFunc(func(o *options) {
+		o.source = true
+	})
+}
+
+// source lazily reads a single line of a source file.
+type source struct {
+	file string
+	line int
+
+	once sync.Once
+	text string
+}
+
+// read returns the line of the file without surrounding whitespace.
+// It returns an empty string if the file cannot be read.
+func (s *source) read() string {
+	s.once.Do(func() {
+		f, err := os.Open(s.file)
+		if err != nil {
+			return
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for line := 1; scanner.Scan(); line++ {
+			if line == s.line {
+				s.text = strings.TrimSpace(scanner.Text())
+				return
+			}
+		}
+	})
+	return s.text
+}
+
+// SourceLine returns the source code of the line the Checkpoint was created at if WithSource was used.
+// It returns an empty string if WithSource was not used or the source file is not available.
+func (e *Checkpoint) SourceLine() string {
+	if e.source == nil {
+		return ""
+	}
+	return e.source.read()
+}
+
+// renderedSource returns the source line rendered below the header of the Checkpoint
+// including the leading line break. It is empty if no source line is available.
+func (e *Checkpoint) renderedSource() string {
+	line := e.SourceLine()
+	if line == "" {
+		return ""
+	}
+	return "\n" + Indent + "> " + line
+}
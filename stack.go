@@ -0,0 +1,203 @@
+package checkpoint
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxStackDepth limits the number of frames recorded by WithStack.
+const maxStackDepth = 64
+
+// callers returns the program counters of the stack starting at the caller of the function calling callers.
+// skip works like the argument to runtime.Caller.
+func callers(skip int) []uintptr {
+	pcs := *stackPool.Get().(*[]uintptr)
+	// Skip runtime.Callers and callers itself.
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// WithStack records the full call stack at the creation of the Checkpoint.
+// It can be retrieved by Checkpoint.Stack and rendered by Checkpoint.StackString.
+// Capturing the stack is more expensive than capturing only the caller, so it is
+// only done for Checkpoints which have this option set.
+func WithStack() Option {
+	return optionFunc(func(o *options) {
+		o.stack = true
+	})
+}
+
+// Stack returns the program counters of the call stack recorded by WithStack,
+// starting at the location the Checkpoint was created.
+// It always contains all frames, even if FilterFrames or FilterStdlib was used.
+// It returns nil if the Checkpoint was created without WithStack.
+func (e *Checkpoint) Stack() []uintptr {
+	return e.stack
+}
+
+// PCs returns the program counters of the call stack recorded by WithStack.
+// It is the same as Stack and exists for symmetry with PC.
+// PCs()[0] is the same as PC().
+func (e *Checkpoint) PCs() []uintptr {
+	return e.stack
+}
+
+// StackTrace returns the program counters of the call stack recorded by WithStack like Stack.
+// It mirrors the StackTracer interface of github.com/pkg/errors, whose StackTrace method returns
+// a slice of uintptr based frames, so tools detecting such stacks by reflection (like ForeignStacks does)
+// find the stack of a Checkpoint without any wrapper.
+// Tools which require exactly the interface of github.com/pkg/errors still need the pkgerrors subpackage.
+// It returns nil if the Checkpoint was created without WithStack.
+func (e *Checkpoint) StackTrace() []uintptr {
+	return e.stack
+}
+
+// StackString returns the call stack recorded by WithStack in a human readable form similar to a panic:
+//
+//	pkg.function
+//		/path/to/file.go:42
+//
+// Frames removed by FilterFrames or FilterStdlib are omitted.
+// If CollapseRepeats is enabled, identical adjacent frames are rendered once with the number of repetitions.
+// It returns an empty string if the Checkpoint was created without WithStack.
+func (e *Checkpoint) StackString() string {
+	return formatStack(e.stack, e.keepFrame)
+}
+
+// formatStack renders the stack of pcs in the format of Checkpoint.StackString.
+// Frames for which keep returns false are omitted; a nil keep keeps all frames.
+func formatStack(pcs []uintptr, keep func(frame runtime.Frame) bool) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	var last runtime.Frame
+	repeats := 0
+	flush := func() {
+		if repeats == 0 {
+			return
+		}
+		b.WriteString(last.Function)
+		b.WriteString("\n\t")
+		b.WriteString(last.File)
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(last.Line))
+		if repeats > 1 {
+			b.WriteString(" (x")
+			b.WriteString(strconv.Itoa(repeats))
+			b.WriteString(")")
+		}
+		b.WriteString("\n")
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if keep == nil || keep(frame) {
+			if CollapseRepeats && repeats > 0 && frame.File == last.File && frame.Line == last.Line {
+				repeats++
+			} else {
+				flush()
+				last, repeats = frame, 1
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// ForeignStacks enables rendering the stacks of errors in the chain which are no Checkpoints
+// but carry their own stack, such as the errors created by github.com/pkg/errors.
+// Such a stack is rendered indented below the message of the error in the format of Checkpoint.StackString.
+// An error carries a stack if it or an error wrapped by it has a method
+//
+//	StackTrace() S
+//
+// where S is a slice of program counters of an uintptr based type, like errors.StackTrace of github.com/pkg/errors.
+var ForeignStacks = false
+
+// foreignStack renders the stack carried by err as lines to append to its rendered message.
+// It returns an empty string if ForeignStacks is disabled or err carries no stack.
+func foreignStack(err error) string {
+	if !ForeignStacks {
+		return ""
+	}
+
+	// Checkpoints wrapped by the error are rendered as part of its message, so their stacks are not used.
+	for i := 0; err != nil && i < maxChainLength && !isCheckpoint(err); i++ {
+		if pcs := stackTracerPCs(err); len(pcs) > 0 {
+			return "\n" + indent(indent(strings.TrimSuffix(formatStack(pcs, nil), "\n")))
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
+
+// stackTracerPCs returns the program counters returned by the StackTrace method of err
+// as described by ForeignStacks. It returns nil if err has no such method.
+func stackTracerPCs(err error) []uintptr {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+	typ := method.Type()
+	if typ.NumIn() != 0 || typ.NumOut() != 1 ||
+		typ.Out(0).Kind() != reflect.Slice || typ.Out(0).Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	pcs := make([]uintptr, trace.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(trace.Index(i).Uint())
+	}
+	return pcs
+}
+
+// FilterFrames omits all frames of the stack recorded by WithStack for which keep returns false
+// when it is rendered by Checkpoint.StackString.
+// The recorded stack itself is not modified, so Checkpoint.Stack still returns all frames.
+// If FilterFrames is used several times, only the last filter is used.
+func FilterFrames(keep func(frame runtime.Frame) bool) Option {
+	return optionFunc(func(o *options) {
+		o.keepFrame = keep
+	})
+}
+
+// FilterStdlib omits all frames of the standard library (including the runtime) from the stack
+// recorded by WithStack when it is rendered by Checkpoint.StackString.
+// A frame belongs to the standard library if its file is located in the GOROOT the program was built with.
+// If the program was built with -trimpath, the GOROOT is unknown and no frames are omitted.
+// It is a FilterFrames filter and therefore replaces any other filter.
+func FilterStdlib() Option {
+	return FilterFrames(func(frame runtime.Frame) bool {
+		return !isStdlib(frame.File)
+	})
+}
+
+// isStdlib reports whether file belongs to the standard library, which means it is located in gorootSrc.
+func isStdlib(file string) bool {
+	root := gorootSrc()
+	return root != "" && strings.HasPrefix(file, root)
+}
+
+// gorootSrc returns the source directory of the GOROOT the program was built with, e.g. "/usr/local/go/src/".
+// It is derived from the file of a runtime function, as runtime.GOROOT reports the GOROOT of the environment
+// the program runs in. It is empty if the program was built with -trimpath.
+var gorootSrc = sync.OnceValue(func() string {
+	pc := reflect.ValueOf(runtime.Gosched).Pointer()
+	file, _ := runtime.FuncForPC(pc).FileLine(pc)
+	i := strings.LastIndex(file, "/runtime/")
+	if i < 0 {
+		return ""
+	}
+	return file[:i+1]
+})
@@ -0,0 +1,39 @@
+package checkpoint
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFilterStdlib(t *testing.T) {
+	var c *Checkpoint
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, _ = asCheckpoint(From(errors.New("failed"), WithStack(), FilterStdlib()))
+	})
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	trace := c.StackString()
+	if !strings.Contains(trace, "checkpoint.TestFilterStdlib") {
+		t.Errorf("StackString() does not contain the frames of the application:\n%s", trace)
+	}
+	for _, stdlib := range []string{"net/http.", "testing.", "runtime."} {
+		if strings.Contains(trace, stdlib) {
+			t.Errorf("StackString() contains frames of %q:\n%s", stdlib, trace)
+		}
+	}
+	if len(c.Stack()) == 0 {
+		t.Errorf("Stack() is empty, want all recorded frames")
+	}
+}
+
+func TestStackTraceDetectedByReflection(t *testing.T) {
+	c, _ := asCheckpoint(From(errors.New("failed"), WithStack()))
+
+	pcs := stackTracerPCs(c)
+	if len(pcs) == 0 || len(pcs) != len(c.Stack()) || pcs[0] != c.Stack()[0] {
+		t.Errorf("StackTrace() is not detected as stack: got %v, want %v", pcs, c.Stack())
+	}
+}
@@ -0,0 +1,78 @@
+package checkpoint
+
+import (
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateNode contains the data passed to the template set by SetTemplate.
+type templateNode struct {
+	File     string
+	Line     int
+	Func     string
+	Package  string
+	Message  string
+	Fields   map[string]interface{}
+	Code     string
+	Severity Severity
+	Time     time.Time
+	// CallerOk is false if no caller information is available.
+	CallerOk bool
+}
+
+func newTemplateNode(c *Checkpoint) templateNode {
+	return templateNode{
+		File:     c.File(),
+		Line:     c.line,
+		Func:     c.fn,
+		Package:  c.pkg,
+		Message:  c.renderedMessage(),
+		Fields:   c.fields,
+		Code:     c.code,
+		Severity: c.severity,
+		Time:     c.time,
+		CallerOk: c.callerOk,
+	}
+}
+
+// SetTemplate sets a template which renders a single Checkpoint in Error().
+// The template can access the fields File, Line, Func, Package, Message, Fields, Code, Severity, Time and CallerOk, e.g.
+//
+//	template.Must(template.New("checkpoint").Parse("{{.File}}:{{.Line}}: {{.Message}}"))
+//
+// Like with SetFormatter, rendering the previous errors is still done by Error().
+// The template is validated by executing it once with sample data and an error is returned if that fails.
+// If the execution fails later while rendering, the default format is used for that Checkpoint.
+// Passing nil restores the default format.
+//
+// SetTemplate replaces any formatter set by SetFormatter and vice versa.
+func SetTemplate(tmpl *template.Template) error {
+	if tmpl == nil {
+		SetFormatter(nil)
+		return nil
+	}
+
+	sample := newTemplateNode(&Checkpoint{
+		err:      io.EOF,
+		callerOk: true,
+		file:     "file.go",
+		line:     1,
+		fn:       "pkg.Func",
+		pkg:      "example.com/pkg",
+		fields:   map[string]interface{}{"key": "value"},
+	})
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return err
+	}
+
+	SetFormatter(func(c *Checkpoint) string {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, newTemplateNode(c)); err != nil {
+			return defaultFormatNode(c)
+		}
+		return b.String()
+	})
+	return nil
+}
@@ -0,0 +1,76 @@
+package checkpoint
+
+import "strings"
+
+// ASCIITree makes Tree use ASCII connectors ("|-", "`-") instead of Unicode box-drawing characters
+// for terminals which cannot render them.
+var ASCIITree = false
+
+// treeConnectors returns the connectors used by Tree for the branches, the last branch
+// and the continuation of branches and the last branch on the following lines.
+func treeConnectors() (branch, last, branchCont, lastCont string) {
+	if ASCIITree {
+		return "|- ", "`- ", "|  ", "   "
+	}
+	return "├─ ", "└─ ", "│  ", "   "
+}
+
+// Tree renders the checkpoint chain as a tree for human readable output, e.g. in command line tools:
+//
+//	main.go:20 (main.main): 2 errors
+//	├─ main.go:12 (main.load): loading failed
+//	│  └─ open config.yml: no such file or directory
+//	└─ main.go:15 (main.save): saving failed
+//
+// Each layer is rendered on a single line like Frame.String, and its previous error is rendered as child.
+// The branches of errors created by WrapMany or Merge are rendered as separate children.
+// See ASCIITree for terminals which cannot render the connectors.
+func (e *Checkpoint) Tree() string {
+	var b strings.Builder
+	var seen visited
+	writeTree(&b, e, "", &seen)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeTree writes the line of err and recursively the lines of its children to b.
+// prefix is written in front of the lines of the children.
+func writeTree(b *strings.Builder, err error, prefix string, seen *visited) {
+	c, ok := asCheckpoint(err)
+	if !ok {
+		b.WriteString(lineBreaks.Replace(err.Error()))
+		b.WriteString("\n")
+		return
+	}
+	if !seen.first(c) {
+		b.WriteString(cycleMarker)
+		b.WriteString("\n")
+		return
+	}
+
+	frame := Frame{Message: lineBreaks.Replace(c.Message())}
+	if c.callerOk {
+		frame.File = c.File()
+		frame.Line = c.line
+		frame.Func = c.fn
+	}
+	b.WriteString(frame.String())
+	b.WriteString("\n")
+
+	var children []error
+	if m, ok := c.prev.(multiError); ok {
+		children = m.errs
+	} else if c.prev != nil {
+		children = []error{c.prev}
+	}
+
+	branch, last, branchCont, lastCont := treeConnectors()
+	for i, child := range children {
+		connector, cont := branch, branchCont
+		if i == len(children)-1 {
+			connector, cont = last, lastCont
+		}
+		b.WriteString(prefix)
+		b.WriteString(connector)
+		writeTree(b, child, prefix+cont, seen)
+	}
+}